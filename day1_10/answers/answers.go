@@ -0,0 +1,142 @@
+package answers
+
+/*
+answers
+
+既知の答えの一覧 (answers.json).
+日, パート, 入力ファイルの組ごとに正しい答えを記録しておき,
+解法を変更したときに答えが変わっていないか確かめる.
+*/
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"Aoc2022/days"
+	"Aoc2022/solver"
+)
+
+// Entry は既知の答え 1 つ分. Input は answers.json からの相対パス
+type Entry struct {
+	Day    int           `json:"day"`
+	Part   int           `json:"part"`
+	Input  string        `json:"input"`
+	Answer solver.Answer `json:"answer"`
+}
+
+func (e Entry) Key() string {
+	return fmt.Sprintf("day%d/part%d/%s", e.Day, e.Part, e.Input)
+}
+
+// Load は path の一覧を読む. 同じ日, パート, 入力の組が重複していたらエラー
+func Load(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []Entry{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	keys := map[string]bool{}
+	for _, entry := range entries {
+		if keys[entry.Key()] {
+			return nil, fmt.Errorf("%s: duplicate entry %s", path, entry.Key())
+		}
+		keys[entry.Key()] = true
+	}
+	return entries, nil
+}
+
+// Save は一覧を日, パート, 入力の順に並べて path に書き出す
+func Save(path string, entries []Entry) error {
+	sorted := append([]Entry{}, entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Day != sorted[j].Day {
+			return sorted[i].Day < sorted[j].Day
+		}
+		if sorted[i].Part != sorted[j].Part {
+			return sorted[i].Part < sorted[j].Part
+		}
+		return sorted[i].Input < sorted[j].Input
+	})
+
+	data, err := json.MarshalIndent(sorted, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Result は既知の答え 1 つを確かめた結果
+type Result struct {
+	Entry Entry
+	Got   solver.Answer
+	Err   error
+}
+
+func (r Result) Ok() bool {
+	return r.Err == nil && r.Got.Equal(r.Entry.Answer)
+}
+
+// Verify は各 Entry の解法を入力ファイルで実行して, 既知の答えと比べる.
+// 入力ファイルは baseDir からの相対パスとして開く. 解法には ctx を渡す
+func Verify(ctx context.Context, entries []Entry, baseDir string) []Result {
+	results := make([]Result, 0, len(entries))
+	for _, entry := range entries {
+		result := Result{Entry: entry}
+		result.Got, result.Err = solve(ctx, entry, baseDir)
+		results = append(results, result)
+	}
+	return results
+}
+
+func solve(ctx context.Context, entry Entry, baseDir string) (solver.Answer, error) {
+	registered, err := days.Lookup(entry.Day, entry.Part)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(baseDir, entry.Input))
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	return registered.Solver.Solve(ctx, bytes.NewReader(data))
+}
+
+// Diff は want と got の違いを - (既知の答え), + (今回の答え) の行で表す.
+// 絵の答えは違う行だけを示す
+func Diff(want solver.Answer, got solver.Answer) string {
+	if want.Kind() != solver.KindGrid || got.Kind() != solver.KindGrid {
+		return fmt.Sprintf("- %s (%s)\n+ %s (%s)\n", want, want.Kind(), got, got.Kind())
+	}
+
+	wantRows := want.Rows()
+	gotRows := got.Rows()
+	builder := strings.Builder{}
+	for idx := 0; idx < len(wantRows) || idx < len(gotRows); idx++ {
+		wantRow, gotRow := row(wantRows, idx), row(gotRows, idx)
+		if wantRow == gotRow {
+			fmt.Fprintf(&builder, "  %s\n", wantRow)
+			continue
+		}
+		fmt.Fprintf(&builder, "- %s\n+ %s\n", wantRow, gotRow)
+	}
+	return builder.String()
+}
+
+func row(rows []string, idx int) string {
+	if idx < len(rows) {
+		return rows[idx]
+	}
+	return ""
+}
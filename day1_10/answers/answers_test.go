@@ -0,0 +1,90 @@
+package answers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"Aoc2022/solver"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+	entries := []Entry{
+		{Day: 5, Part: 1, Input: "day5.txt", Answer: solver.String("CMZ")},
+		{Day: 1, Part: 2, Input: "day1.txt", Answer: solver.Int(45000)},
+		{Day: 10, Part: 2, Input: "day10.txt", Answer: solver.Grid([]string{"#.", ".#"})},
+	}
+
+	if err := Save(path, entries); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Entry{entries[1], entries[0], entries[2]}
+	if !reflect.DeepEqual(loaded, want) {
+		t.Errorf("got %+v, want %+v", loaded, want)
+	}
+}
+
+func TestLoadRejectsDuplicates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+	data := `[
+		{"day": 1, "part": 1, "input": "a.txt", "answer": 1},
+		{"day": 1, "part": 1, "input": "a.txt", "answer": 2}
+	]`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "duplicate") {
+		t.Errorf("expected a duplicate error, got %v", err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+	input := "1000\n2000\n\n3000\n"
+	if err := os.WriteFile(filepath.Join(dir, "day1.txt"), []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	results := Verify(context.Background(), []Entry{
+		{Day: 1, Part: 1, Input: "day1.txt", Answer: solver.Int(3000)},
+		{Day: 1, Part: 1, Input: "day1.txt", Answer: solver.Int(2999)},
+		{Day: 1, Part: 1, Input: "missing.txt", Answer: solver.Int(3000)},
+		{Day: 99, Part: 1, Input: "day1.txt", Answer: solver.Int(3000)},
+	}, dir)
+
+	if !results[0].Ok() {
+		t.Errorf("expected the correct answer to pass: %+v", results[0])
+	}
+	if results[1].Ok() || results[1].Err != nil {
+		t.Errorf("expected a changed answer without error: %+v", results[1])
+	}
+	if results[2].Err == nil {
+		t.Error("expected an error for a missing input")
+	}
+	if results[3].Err == nil {
+		t.Error("expected an error for an unknown day")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	got := Diff(solver.Int(24000), solver.Int(24001))
+	if got != "- 24000 (int)\n+ 24001 (int)\n" {
+		t.Errorf("unexpected diff:\n%s", got)
+	}
+
+	got = Diff(solver.Grid([]string{"##", "..", "#."}), solver.Grid([]string{"##", ".#"}))
+	want := "  ##\n- ..\n+ .#\n- #.\n+ \n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
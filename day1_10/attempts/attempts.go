@@ -0,0 +1,149 @@
+package attempts
+
+/*
+attempts
+
+サイトに送った答えの記録 (submissions.json).
+送る前に記録と照らし合わせ, 間違いと分かっている答えは送らない.
+
+* 前に不正解だった答えは送らない
+* 大きすぎた答え以上, 小さすぎた答え以下の数は送らない
+* 正解済みのパートには送らない
+* 解くレベルが違うと言われても送るのは止めない. Part1 を解く前に Part2 を送ったときも同じ文面が返るため
+* サイトに待てと言われている間は送らない
+*/
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"Aoc2022/site"
+)
+
+var (
+	ErrSolved     = errors.New("already solved")
+	ErrKnownWrong = errors.New("already submitted and rejected")
+	ErrOutOfRange = errors.New("outside the known bounds")
+	ErrWait       = errors.New("submitted too recently")
+)
+
+// Attempt は答えを 1 回送った記録
+type Attempt struct {
+	Day     int          `json:"day"`
+	Part    int          `json:"part"`
+	Answer  string       `json:"answer"`
+	Outcome site.Outcome `json:"outcome"`
+	Time    time.Time    `json:"time"`
+	// この時刻まで次の答えを送れない. なければゼロ値
+	RetryAfter time.Time `json:"retry_after"`
+}
+
+// Log は送った答えの記録を古い順に持つ
+type Log struct {
+	Attempts []Attempt
+}
+
+// Load は path の記録を読む. ファイルがなければ空の記録を返す
+func Load(path string) (*Log, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Log{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log := &Log{}
+	if err := json.Unmarshal(data, &log.Attempts); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return log, nil
+}
+
+func (l *Log) Save(path string) error {
+	data, err := json.MarshalIndent(l.Attempts, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Record は verdict を受け取った記録を足す
+func (l *Log) Record(day int, part int, answer string, verdict site.Verdict, now time.Time) Attempt {
+	attempt := Attempt{Day: day, Part: part, Answer: answer, Outcome: verdict.Outcome, Time: now}
+	if verdict.Wait > 0 {
+		attempt.RetryAfter = now.Add(verdict.Wait)
+	}
+	l.Attempts = append(l.Attempts, attempt)
+	return attempt
+}
+
+// Bounds は day 日目の part で分かっている答えの範囲 (low, high) を返す. 答えは low より大きく high より小さい.
+// 大きすぎた, 小さすぎた答えがなければ hasLow, hasHigh が false になる
+func (l *Log) Bounds(day int, part int) (low int, hasLow bool, high int, hasHigh bool) {
+	for _, attempt := range l.Attempts {
+		if attempt.Day != day || attempt.Part != part {
+			continue
+		}
+		value, err := strconv.Atoi(attempt.Answer)
+		if err != nil {
+			continue
+		}
+		switch attempt.Outcome {
+		case site.TooLow:
+			if !hasLow || low < value {
+				low, hasLow = value, true
+			}
+		case site.TooHigh:
+			if !hasHigh || value < high {
+				high, hasHigh = value, true
+			}
+		}
+	}
+	return low, hasLow, high, hasHigh
+}
+
+// Check は answer を now に送ってよいか確かめ, 送っても無駄なら理由をエラーで返す
+func (l *Log) Check(day int, part int, answer string, now time.Time) error {
+	for _, attempt := range l.Attempts {
+		// 待ち時間は日やパートによらずかかる
+		if now.Before(attempt.RetryAfter) {
+			return fmt.Errorf("%w: wait until %s", ErrWait, attempt.RetryAfter.Format("15:04:05"))
+		}
+		if attempt.Day != day || attempt.Part != part {
+			continue
+		}
+		switch attempt.Outcome {
+		case site.Correct:
+			return fmt.Errorf("day %d part %d: %w with %s", day, part, ErrSolved, attempt.Answer)
+		case site.TooHigh, site.TooLow, site.Wrong:
+			if attempt.Answer == answer {
+				return fmt.Errorf("day %d part %d: %s %w (%s)", day, part, answer, ErrKnownWrong, attempt.Outcome)
+			}
+		}
+	}
+
+	value, err := strconv.Atoi(answer)
+	if err != nil {
+		return nil
+	}
+	low, hasLow, high, hasHigh := l.Bounds(day, part)
+	if (hasLow && value <= low) || (hasHigh && high <= value) {
+		return fmt.Errorf("day %d part %d: %s is %w (%s)", day, part, answer, ErrOutOfRange, describeBounds(low, hasLow, high, hasHigh))
+	}
+	return nil
+}
+
+func describeBounds(low int, hasLow bool, high int, hasHigh bool) string {
+	switch {
+	case hasLow && hasHigh:
+		return fmt.Sprintf("between %d and %d", low, high)
+	case hasLow:
+		return fmt.Sprintf("greater than %d", low)
+	}
+	return fmt.Sprintf("less than %d", high)
+}
@@ -0,0 +1,109 @@
+package attempts
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"Aoc2022/site"
+)
+
+var start = time.Date(2022, 12, 1, 5, 0, 0, 0, time.UTC)
+
+func TestCheck(t *testing.T) {
+	log := &Log{}
+	now := start
+	log.Record(1, 1, "1000", site.Verdict{Outcome: site.TooLow}, now)
+	log.Record(1, 1, "5000", site.Verdict{Outcome: site.TooHigh}, now)
+	log.Record(1, 1, "abc", site.Verdict{Outcome: site.Wrong}, now)
+	log.Record(2, 1, "15", site.Verdict{Outcome: site.Correct}, now)
+	// Part1 を解く前に Part2 を送った
+	log.Record(3, 2, "70", site.Verdict{Outcome: site.WrongLevel}, now)
+
+	tests := []struct {
+		day, part int
+		answer    string
+		want      error
+	}{
+		{1, 1, "3000", nil},
+		{1, 1, "1000", ErrKnownWrong},
+		{1, 1, "999", ErrOutOfRange},
+		{1, 1, "5000", ErrKnownWrong},
+		{1, 1, "9000", ErrOutOfRange},
+		{1, 1, "abc", ErrKnownWrong},
+		{1, 1, "xyz", nil},
+		{1, 2, "1000", nil},
+		{2, 1, "15", ErrSolved},
+		{2, 1, "16", ErrSolved},
+		{2, 2, "16", nil},
+		{3, 2, "70", nil},
+		{3, 2, "71", nil},
+	}
+	for _, test := range tests {
+		err := log.Check(test.day, test.part, test.answer, now)
+		if !errors.Is(err, test.want) || (test.want == nil && err != nil) {
+			t.Errorf("day %d part %d %s: got %v, want %v", test.day, test.part, test.answer, err, test.want)
+		}
+	}
+}
+
+func TestBounds(t *testing.T) {
+	log := &Log{}
+	if _, hasLow, _, hasHigh := log.Bounds(1, 1); hasLow || hasHigh {
+		t.Error("expected no bounds")
+	}
+
+	for _, attempt := range []struct {
+		answer  string
+		outcome site.Outcome
+	}{{"10", site.TooLow}, {"30", site.TooLow}, {"20", site.TooLow}, {"90", site.TooHigh}, {"70", site.TooHigh}, {"80", site.TooHigh}} {
+		log.Record(1, 1, attempt.answer, site.Verdict{Outcome: attempt.outcome}, start)
+	}
+	low, hasLow, high, hasHigh := log.Bounds(1, 1)
+	if !hasLow || low != 30 || !hasHigh || high != 70 {
+		t.Errorf("got (%d, %v, %d, %v), want (30, true, 70, true)", low, hasLow, high, hasHigh)
+	}
+}
+
+func TestCheckWait(t *testing.T) {
+	log := &Log{}
+	log.Record(5, 1, "ABC", site.Verdict{Outcome: site.Wrong, Wait: time.Minute}, start)
+
+	// 別の日でも待ち時間の間は送らない
+	if err := log.Check(6, 1, "7", start.Add(30*time.Second)); !errors.Is(err, ErrWait) {
+		t.Errorf("got %v, want ErrWait", err)
+	}
+	if err := log.Check(6, 1, "7", start.Add(time.Minute)); err != nil {
+		t.Errorf("got %v after the wait", err)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "submissions.json")
+
+	log, err := Load(path)
+	if err != nil || len(log.Attempts) != 0 {
+		t.Fatalf("got %v, %v, want an empty log", log, err)
+	}
+
+	log.Record(1, 1, "100", site.Verdict{Outcome: site.TooLow, Wait: time.Minute}, start)
+	log.Record(1, 1, "200", site.Verdict{Outcome: site.Correct}, start.Add(2*time.Minute))
+	if err := log.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Attempts) != 2 {
+		t.Fatalf("got %d attempts, want 2", len(loaded.Attempts))
+	}
+	for idx, attempt := range loaded.Attempts {
+		want := log.Attempts[idx]
+		if attempt.Answer != want.Answer || attempt.Outcome != want.Outcome || !attempt.Time.Equal(want.Time) || !attempt.RetryAfter.Equal(want.RetryAfter) {
+			t.Errorf("attempt %d: got %+v, want %+v", idx, attempt, want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"Aoc2022/batch"
+	"Aoc2022/days"
+	"Aoc2022/params"
+	"Aoc2022/tracing"
+)
+
+func batchCommand(args []string) error {
+	fs := flag.NewFlagSet("batch", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to run")
+	part := fs.Int("part", 0, "part to run (1 or 2); both parts when omitted")
+	dir := fs.String("dir", "", "`directory` of inputs; alice.txt is checked against alice.expected when present")
+	workers := fs.Int("j", 1, "number of parts to run in parallel")
+	timeout := fs.Duration("timeout", 0, "time limit per part (0 for none)")
+	traceLevel := fs.String("trace-level", "off", "write solver progress to standard error: off, info, debug or verbose")
+	reference := fs.Bool("reference", false, "run the unoptimized reference solvers instead (days with a reference only)")
+	configPath, overrides := paramFlags(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	if *day == 0 || *dir == "" {
+		return errors.New("-day and -dir are required")
+	}
+
+	level, err := tracing.ParseLevel(*traceLevel)
+	if err != nil {
+		return err
+	}
+	tracing.SetLevel(level)
+
+	entries, err := selectEntries(*day, *part)
+	if err != nil {
+		return err
+	}
+	if *reference {
+		for idx, entry := range entries {
+			if entry.Reference == nil {
+				return fmt.Errorf("day %d has no reference solver (available: %v)", entry.Day, days.ReferenceDays())
+			}
+			entries[idx].Solver = entry.Reference
+		}
+	}
+
+	values, err := loadParams(*configPath, overrides)
+	if err != nil {
+		return err
+	}
+
+	inputs, err := batch.Inputs(*dir)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	ctx = params.With(ctx, values)
+	rows := batch.Run(ctx, entries, inputs, *workers, *timeout)
+	if err := batch.Write(os.Stdout, rows); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, row := range rows {
+		for _, cell := range row.Cells {
+			if cell.Failed() {
+				failed++
+			}
+		}
+	}
+	if failed != 0 {
+		return fmt.Errorf("%d part(s) failed", failed)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package batch
+
+/*
+batch
+
+1 日分の解法を, ディレクトリにある全ての入力で解いて確かめる.
+色々な人の入力を集めておき, 作者の入力以外でも正しく解けるか確かめるのに使う.
+
+入力 alice.txt の答えが分かっていれば, 横に alice.expected を置く.
+中身はパートの番号から答えへの JSON で, 答えの形は answers.json と同じ.
+分からないパートは書かなくてよい.
+
+	{"1": 24000, "2": 45000}
+*/
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"Aoc2022/days"
+	"Aoc2022/runner"
+	"Aoc2022/solver"
+)
+
+// 答えを書いておくファイルの拡張子
+const ExpectedExt = ".expected"
+
+// Input は入力 1 つと, 分かっている答え
+type Input struct {
+	Path string
+	// パートの番号から答えへ
+	Expected map[int]solver.Answer
+}
+
+// Inputs は dir にある入力を名前順に返す. *.expected と . で始まるファイルは入力とみなさない
+func Inputs(dir string) ([]Input, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []Input{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || filepath.Ext(name) == ExpectedExt {
+			continue
+		}
+
+		input := Input{Path: filepath.Join(dir, name)}
+		if input.Expected, err = readExpected(expectedPath(input.Path)); err != nil {
+			return nil, err
+		}
+		result = append(result, input)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("%s: no inputs", dir)
+	}
+	return result, nil
+}
+
+// expectedPath は alice.txt の答えのファイル alice.expected を返す
+func expectedPath(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ExpectedExt
+}
+
+// readExpected は答えのファイルを読む. なければ空
+func readExpected(path string) (map[int]solver.Answer, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[int]solver.Answer{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := map[string]solver.Answer{}
+	if err := json.Unmarshal(data, &byKey); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	result := map[int]solver.Answer{}
+	for key, answer := range byKey {
+		part, err := strconv.Atoi(key)
+		if err != nil || part < 1 {
+			return nil, fmt.Errorf("%s: %q is not a part number", path, key)
+		}
+		result[part] = answer
+	}
+	return result, nil
+}
+
+// Cell は入力 1 つ, パート 1 つ分の結果
+type Cell struct {
+	Result   runner.Result
+	Expected solver.Answer
+	// 答えが分かっていたかどうか
+	HasExpected bool
+}
+
+// Status は pass, fail (答えが違う), ok (答えが分からないが解けた), error, timeout のいずれか
+func (c Cell) Status() string {
+	switch {
+	case c.Result.Err != nil:
+		return c.Result.Status()
+	case !c.HasExpected:
+		return "ok"
+	case c.Result.Answer.Equal(c.Expected):
+		return "pass"
+	}
+	return "fail"
+}
+
+// Failed はこのセルを失敗として数えるかどうか
+func (c Cell) Failed() bool {
+	status := c.Status()
+	return status != "pass" && status != "ok"
+}
+
+// Row は入力 1 つ分の結果. Cells はパートの順
+type Row struct {
+	Input string
+	Cells []Cell
+}
+
+// Run は全ての入力を entries の全パートで解く. workers, timeout は runner と同じ
+func Run(ctx context.Context, entries []days.Entry, inputs []Input, workers int, timeout time.Duration) []Row {
+	jobs := make([]runner.Job, 0, len(inputs)*len(entries))
+	for _, input := range inputs {
+		for _, entry := range entries {
+			jobs = append(jobs, runner.Job{Entry: entry, Input: input.Path, Timeout: timeout})
+		}
+	}
+	results := runner.RunAll(ctx, jobs, workers)
+
+	rows := make([]Row, len(inputs))
+	for idx, input := range inputs {
+		rows[idx] = Row{Input: input.Path, Cells: make([]Cell, len(entries))}
+		for col, entry := range entries {
+			expected, exists := input.Expected[entry.Part]
+			rows[idx].Cells[col] = Cell{Result: results[idx*len(entries)+col], Expected: expected, HasExpected: exists}
+		}
+	}
+	return rows
+}
+
+// Write は結果を表にして書き, その下に失敗の詳細と集計を書く
+func Write(w io.Writer, rows []Row) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprint(tw, "input")
+	for idx, cell := range rows[0].Cells {
+		// 見出しは答えの列に置き, 時間の列は空ける
+		if idx != 0 {
+			fmt.Fprint(tw, "\t")
+		}
+		fmt.Fprintf(tw, "\tpart%d", cell.Result.Part)
+	}
+	fmt.Fprintln(tw)
+	for _, row := range rows {
+		fmt.Fprint(tw, filepath.Base(row.Input))
+		for _, cell := range row.Cells {
+			fmt.Fprintf(tw, "\t%s\t%s", cell.Status(), cell.Result.Duration.Round(time.Microsecond))
+		}
+		fmt.Fprintln(tw)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	counts := map[string]int{}
+	details := []string{}
+	for _, row := range rows {
+		for _, cell := range row.Cells {
+			counts[cell.Status()]++
+			header := fmt.Sprintf("%s part%d", filepath.Base(row.Input), cell.Result.Part)
+			switch cell.Status() {
+			case "fail":
+				details = append(details, fmt.Sprintf("%s: got %s, want %s", header, oneLine(cell.Result.Answer), oneLine(cell.Expected)))
+			case "error":
+				details = append(details, fmt.Sprintf("%s: %v", header, cell.Result.Err))
+			case "timeout":
+				details = append(details, fmt.Sprintf("%s: timeout after %s", header, cell.Result.Duration))
+			}
+		}
+	}
+	if len(details) != 0 {
+		fmt.Fprintf(w, "\n%s\n", strings.Join(details, "\n"))
+	}
+
+	statuses := []string{}
+	for _, status := range []string{"pass", "fail", "ok", "error", "timeout"} {
+		if counts[status] != 0 {
+			statuses = append(statuses, fmt.Sprintf("%d %s", counts[status], status))
+		}
+	}
+	_, err := fmt.Fprintf(w, "\n%d input(s): %s\n", len(rows), strings.Join(statuses, ", "))
+	return err
+}
+
+// 絵の答えは行を / でつないで 1 行にする
+func oneLine(answer solver.Answer) string {
+	if answer.Kind() == solver.KindGrid {
+		return strings.Join(answer.Rows(), "/")
+	}
+	return answer.String()
+}
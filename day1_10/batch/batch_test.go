@@ -0,0 +1,137 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"Aoc2022/days"
+	"Aoc2022/solver"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// 入力の行数を答える解法と, 行数の 2 倍を答える解法
+func countLines(scale int) solver.Func {
+	return func(ctx context.Context, r io.Reader) (solver.Answer, error) {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return solver.Answer{}, err
+		}
+		if bytes.Contains(data, []byte("boom")) {
+			return solver.Answer{}, errors.New("boom")
+		}
+		if bytes.Contains(data, []byte("slow")) {
+			<-ctx.Done()
+			return solver.Answer{}, ctx.Err()
+		}
+		return solver.Int(scale * bytes.Count(data, []byte("\n"))), nil
+	}
+}
+
+var entries = []days.Entry{
+	{Day: 1, Part: 1, Solver: countLines(1)},
+	{Day: 1, Part: 2, Solver: countLines(2)},
+}
+
+func TestInputs(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"bob.txt":        "1\n",
+		"alice.txt":      "1\n2\n",
+		"alice.expected": `{"1": 2, "2": 4}`,
+		".DS_Store":      "",
+	})
+	os.Mkdir(filepath.Join(dir, "nested"), 0o755)
+
+	inputs, err := Inputs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) != 2 || filepath.Base(inputs[0].Path) != "alice.txt" || filepath.Base(inputs[1].Path) != "bob.txt" {
+		t.Fatalf("got %+v", inputs)
+	}
+	if !inputs[0].Expected[1].Equal(solver.Int(2)) || !inputs[0].Expected[2].Equal(solver.Int(4)) || len(inputs[1].Expected) != 0 {
+		t.Errorf("got %+v", inputs)
+	}
+}
+
+func TestInputsErrors(t *testing.T) {
+	if _, err := Inputs(writeFiles(t, map[string]string{"a.expected": `{"1": 1}`})); err == nil {
+		t.Error("expected an error for a directory without inputs")
+	}
+	if _, err := Inputs(writeFiles(t, map[string]string{"a.txt": "", "a.expected": `{"one": 1}`})); err == nil {
+		t.Error("expected an error for a bad part number")
+	}
+	if _, err := Inputs(writeFiles(t, map[string]string{"a.txt": "", "a.expected": `[1, 2]`})); err == nil {
+		t.Error("expected an error for a malformed sidecar")
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.txt":      "1\n2\n",
+		"a.expected": `{"1": 2, "2": 4}`,
+		"b.txt":      "1\n",
+		"b.expected": `{"1": 1, "2": 3}`,
+		"c.txt":      "1\n2\n3\n",
+		"d.txt":      "boom\n",
+		"e.txt":      "slow\n",
+	})
+	inputs, err := Inputs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := Run(context.Background(), entries, inputs, 4, 20*time.Millisecond)
+	want := [][]string{
+		{"pass", "pass"},
+		{"pass", "fail"},
+		{"ok", "ok"},
+		{"error", "error"},
+		{"timeout", "timeout"},
+	}
+	for idx, row := range rows {
+		for col, cell := range row.Cells {
+			if cell.Status() != want[idx][col] {
+				t.Errorf("%s part%d: got %s, want %s", filepath.Base(row.Input), col+1, cell.Status(), want[idx][col])
+			}
+			if cell.Failed() != (want[idx][col] != "pass" && want[idx][col] != "ok") {
+				t.Errorf("%s part%d: Failed() = %v", filepath.Base(row.Input), col+1, cell.Failed())
+			}
+		}
+	}
+
+	buffer := bytes.Buffer{}
+	if err := Write(&buffer, rows); err != nil {
+		t.Fatal(err)
+	}
+	output := buffer.String()
+	for _, want := range []string{
+		"input  part1",
+		"b.txt  pass",
+		"b.txt part2: got 2, want 3",
+		"d.txt part1: boom",
+		"e.txt part2: timeout after",
+		"5 input(s): 3 pass, 1 fail, 2 ok, 2 error, 2 timeout",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output does not contain %q:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+
+	"Aoc2022/bench"
+	"Aoc2022/days"
+	"Aoc2022/params"
+)
+
+func benchCommand(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to benchmark; every registered day when omitted")
+	part := fs.Int("part", 0, "part to benchmark (1 or 2); both parts when omitted")
+	inputDir := fs.String("inputs", defaultInputDir, "directory holding dayN.txt")
+	benchtime := fs.String("benchtime", "1s", "run each part for `d` or N times if d is of the form Nx")
+	save := fs.String("save", "", "save the results as a JSON baseline to `file`")
+	baseline := fs.String("baseline", "", "compare the results with the JSON baseline in `file`")
+	threshold := fs.Float64("threshold", 0.1, "report metrics that grew by more than this ratio over the baseline")
+	configPath, overrides := paramFlags(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	if *part != 0 && *day == 0 {
+		return errors.New("-part requires -day")
+	}
+	values, err := loadParams(*configPath, overrides)
+	if err != nil {
+		return err
+	}
+
+	// testing.Benchmark は -test.benchtime の値で回数を決める
+	testing.Init()
+	if err := flag.Set("test.benchtime", *benchtime); err != nil {
+		return fmt.Errorf("invalid -benchtime: %w", err)
+	}
+
+	entries := days.All()
+	if *day != 0 {
+		selected, err := selectEntries(*day, *part)
+		if err != nil {
+			return err
+		}
+		entries = selected
+	}
+
+	ctx := params.With(context.Background(), values)
+	results := []bench.Result{}
+	failed := 0
+	for _, entry := range entries {
+		data, err := readInput(inputFile(*inputDir, entry.Day))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "day%d part%d: %v\n", entry.Day, entry.Part, err)
+			failed++
+			continue
+		}
+
+		result, err := bench.Run(ctx, entry, data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "day%d part%d: %v\n", entry.Day, entry.Part, err)
+			failed++
+			continue
+		}
+		results = append(results, result)
+	}
+
+	if err := bench.WriteTable(os.Stdout, results); err != nil {
+		return err
+	}
+
+	if *save != "" {
+		if err := bench.Save(*save, results); err != nil {
+			return err
+		}
+	}
+
+	if *baseline != "" {
+		before, err := bench.Load(*baseline)
+		if err != nil {
+			return err
+		}
+
+		regressions := bench.Compare(before, results, *threshold)
+		for _, regression := range regressions {
+			fmt.Fprintln(os.Stderr, "regression:", regression)
+		}
+		if len(regressions) != 0 {
+			return fmt.Errorf("%d regression(s) over %.0f%%", len(regressions), *threshold*100)
+		}
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("%d part(s) could not be benchmarked", failed)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package bench
+
+/*
+bench
+
+各パートの解法を testing.Benchmark で繰り返し実行して,
+1 回あたりの時間とアロケーションを測る.
+結果を JSON のベースラインとして保存しておけば, 後の計測と比べて遅くなったパートを見つけられる.
+*/
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"text/tabwriter"
+
+	"Aoc2022/days"
+)
+
+// Result は 1 パート分の計測結果
+type Result struct {
+	Day         int   `json:"day"`
+	Part        int   `json:"part"`
+	N           int   `json:"n"`
+	NsPerOp     int64 `json:"ns_per_op"`
+	AllocsPerOp int64 `json:"allocs_per_op"`
+	BytesPerOp  int64 `json:"bytes_per_op"`
+}
+
+// Run は data を入力として entry を計測する.
+// 計測の前に 1 度解いてみて, 失敗するならそのエラーを返す. 解法には ctx を渡す
+func Run(ctx context.Context, entry days.Entry, data []byte) (Result, error) {
+	if _, err := entry.Solver.Solve(ctx, bytes.NewReader(data)); err != nil {
+		return Result{}, err
+	}
+
+	benchmark := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			entry.Solver.Solve(ctx, bytes.NewReader(data))
+		}
+	})
+
+	return Result{
+		Day:         entry.Day,
+		Part:        entry.Part,
+		N:           benchmark.N,
+		NsPerOp:     benchmark.NsPerOp(),
+		AllocsPerOp: benchmark.AllocsPerOp(),
+		BytesPerOp:  benchmark.AllocedBytesPerOp(),
+	}, nil
+}
+
+// WriteTable は計測結果を表にして書き出す
+func WriteTable(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "day\tpart\tn\tns/op\tallocs/op\tB/op\t")
+	for _, result := range results {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%d\t%d\t\n",
+			result.Day, result.Part, result.N, result.NsPerOp, result.AllocsPerOp, result.BytesPerOp)
+	}
+	return tw.Flush()
+}
+
+// Save は計測結果をベースラインとして path に保存する
+func Save(path string, results []Result) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Load は Save したベースラインを読む
+func Load(path string) ([]Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []Result{}
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return results, nil
+}
+
+// Regression はベースラインより悪化した指標 1 つ分
+type Regression struct {
+	Day      int
+	Part     int
+	Metric   string
+	Baseline int64
+	Current  int64
+}
+
+func (r Regression) Ratio() float64 {
+	if r.Baseline == 0 {
+		return 0
+	}
+	return float64(r.Current)/float64(r.Baseline) - 1
+}
+
+func (r Regression) String() string {
+	return fmt.Sprintf("day%d part%d: %s %d -> %d (+%.1f%%)", r.Day, r.Part, r.Metric, r.Baseline, r.Current, r.Ratio()*100)
+}
+
+// Compare は current のうち, ベースラインから threshold (0.1 なら 10%) を超えて悪化した指標を返す.
+// ベースラインにないパートは比べない
+func Compare(baseline []Result, current []Result, threshold float64) []Regression {
+	type key struct{ day, part int }
+	base := map[key]Result{}
+	for _, result := range baseline {
+		base[key{result.Day, result.Part}] = result
+	}
+
+	regressions := []Regression{}
+	for _, result := range current {
+		before, exists := base[key{result.Day, result.Part}]
+		if !exists {
+			continue
+		}
+
+		metrics := []struct {
+			name            string
+			before, current int64
+		}{
+			{"ns/op", before.NsPerOp, result.NsPerOp},
+			{"allocs/op", before.AllocsPerOp, result.AllocsPerOp},
+			{"B/op", before.BytesPerOp, result.BytesPerOp},
+		}
+		for _, metric := range metrics {
+			if float64(metric.before)*(1+threshold) < float64(metric.current) {
+				regressions = append(regressions, Regression{
+					Day:      result.Day,
+					Part:     result.Part,
+					Metric:   metric.name,
+					Baseline: metric.before,
+					Current:  metric.current,
+				})
+			}
+		}
+	}
+	return regressions
+}
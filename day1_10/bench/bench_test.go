@@ -0,0 +1,89 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"Aoc2022/days"
+	"Aoc2022/solver"
+)
+
+func TestRun(t *testing.T) {
+	if err := flag.Set("test.benchtime", "10x"); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := days.Entry{Day: 1, Part: 2, Solver: solver.Func(func(ctx context.Context, r io.Reader) (solver.Answer, error) {
+		data, err := io.ReadAll(r)
+		return solver.Int(len(data)), err
+	})}
+
+	result, err := Run(context.Background(), entry, []byte("abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Day != 1 || result.Part != 2 || result.N == 0 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestRunReportsSolverError(t *testing.T) {
+	want := errors.New("broken")
+	entry := days.Entry{Day: 1, Part: 1, Solver: solver.Func(func(ctx context.Context, r io.Reader) (solver.Answer, error) {
+		return solver.Answer{}, want
+	})}
+
+	if _, err := Run(context.Background(), entry, nil); !errors.Is(err, want) {
+		t.Errorf("got %v, want %v", err, want)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	results := []Result{
+		{Day: 8, Part: 1, N: 100, NsPerOp: 12345, AllocsPerOp: 10, BytesPerOp: 2048},
+		{Day: 9, Part: 2, N: 50, NsPerOp: 999, AllocsPerOp: 0, BytesPerOp: 0},
+	}
+
+	if err := Save(path, results); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, results) {
+		t.Errorf("got %+v, want %+v", loaded, results)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := []Result{
+		{Day: 8, Part: 1, NsPerOp: 1000, AllocsPerOp: 10, BytesPerOp: 100},
+		{Day: 8, Part: 2, NsPerOp: 1000, AllocsPerOp: 10, BytesPerOp: 100},
+	}
+	current := []Result{
+		// 10% 以内なら悪化とみなさない
+		{Day: 8, Part: 1, NsPerOp: 1100, AllocsPerOp: 10, BytesPerOp: 90},
+		{Day: 8, Part: 2, NsPerOp: 1500, AllocsPerOp: 12, BytesPerOp: 100},
+		// ベースラインにないパート
+		{Day: 9, Part: 1, NsPerOp: 99999},
+	}
+
+	got := Compare(baseline, current, 0.1)
+	want := []Regression{
+		{Day: 8, Part: 2, Metric: "ns/op", Baseline: 1000, Current: 1500},
+		{Day: 8, Part: 2, Metric: "allocs/op", Baseline: 10, Current: 12},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if ratio := got[0].Ratio(); ratio != 0.5 {
+		t.Errorf("got ratio %v, want 0.5", ratio)
+	}
+}
@@ -0,0 +1,106 @@
+package collections
+
+// Deque は両端から出し入れできるキュー. リングバッファで持つ. ゼロ値は空の Deque
+type Deque[T any] struct {
+	items []T
+	head  int
+	size  int
+}
+
+func NewDeque[T any](items ...T) *Deque[T] {
+	d := &Deque[T]{}
+	for _, item := range items {
+		d.PushBack(item)
+	}
+	return d
+}
+
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+// 容量が足りなければ倍に広げ, 先頭を 0 番目に詰め直す
+func (d *Deque[T]) grow() {
+	if d.size < len(d.items) {
+		return
+	}
+
+	capacity := len(d.items) * 2
+	if capacity == 0 {
+		capacity = 8
+	}
+
+	items := make([]T, capacity)
+	for idx := 0; idx < d.size; idx++ {
+		items[idx] = d.items[(d.head+idx)%len(d.items)]
+	}
+	d.items = items
+	d.head = 0
+}
+
+func (d *Deque[T]) PushBack(item T) {
+	d.grow()
+	d.items[(d.head+d.size)%len(d.items)] = item
+	d.size++
+}
+
+func (d *Deque[T]) PushFront(item T) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.items)) % len(d.items)
+	d.items[d.head] = item
+	d.size++
+}
+
+// PopFront は先頭の要素を取り出す. 空なら false
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+
+	item := d.items[d.head]
+	d.items[d.head] = zero
+	d.head = (d.head + 1) % len(d.items)
+	d.size--
+	return item, true
+}
+
+// PopBack は末尾の要素を取り出す. 空なら false
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+
+	idx := (d.head + d.size - 1) % len(d.items)
+	item := d.items[idx]
+	d.items[idx] = zero
+	d.size--
+	return item, true
+}
+
+// Front は先頭の要素を取り出さずに返す. 空なら false
+func (d *Deque[T]) Front() (T, bool) {
+	if d.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.items[d.head], true
+}
+
+// Back は末尾の要素を取り出さずに返す. 空なら false
+func (d *Deque[T]) Back() (T, bool) {
+	if d.size == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.items[(d.head+d.size-1)%len(d.items)], true
+}
+
+// At は先頭から idx 番目の要素を返す. 範囲外なら panic する
+func (d *Deque[T]) At(idx int) T {
+	if idx < 0 || d.size <= idx {
+		panic("collections: Deque index out of range")
+	}
+	return d.items[(d.head+idx)%len(d.items)]
+}
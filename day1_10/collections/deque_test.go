@@ -0,0 +1,89 @@
+package collections
+
+import (
+	"testing"
+)
+
+func contents[T any](d *Deque[T]) []T {
+	result := []T{}
+	for idx := 0; idx < d.Len(); idx++ {
+		result = append(result, d.At(idx))
+	}
+	return result
+}
+
+func TestDeque(t *testing.T) {
+	d := NewDeque(2, 3)
+	d.PushFront(1)
+	d.PushBack(4)
+
+	got := contents(d)
+	if len(got) != 4 || got[0] != 1 || got[3] != 4 {
+		t.Fatalf("got %v", got)
+	}
+
+	if front, ok := d.Front(); !ok || front != 1 {
+		t.Errorf("Front: got %d, %t", front, ok)
+	}
+	if back, ok := d.Back(); !ok || back != 4 {
+		t.Errorf("Back: got %d, %t", back, ok)
+	}
+
+	if front, ok := d.PopFront(); !ok || front != 1 {
+		t.Errorf("PopFront: got %d, %t", front, ok)
+	}
+	if back, ok := d.PopBack(); !ok || back != 4 {
+		t.Errorf("PopBack: got %d, %t", back, ok)
+	}
+	if d.Len() != 2 {
+		t.Errorf("got len %d, want 2", d.Len())
+	}
+}
+
+func TestDequeGrowsAcrossWrap(t *testing.T) {
+	d := &Deque[int]{}
+	// 先頭側と末尾側に交互に積んでリングバッファを折り返させる
+	for i := 1; i <= 20; i++ {
+		if i%2 == 0 {
+			d.PushBack(i)
+		} else {
+			d.PushFront(-i)
+		}
+	}
+
+	got := contents(d)
+	if len(got) != 20 || got[0] != -19 || got[9] != -1 || got[10] != 2 || got[19] != 20 {
+		t.Errorf("got %v", got)
+	}
+
+	for i := 0; i < 20; i++ {
+		if _, ok := d.PopFront(); !ok {
+			t.Fatalf("PopFront failed after %d items", i)
+		}
+	}
+	if _, ok := d.PopBack(); ok {
+		t.Error("expected PopBack on an empty deque to fail")
+	}
+	if _, ok := d.Front(); ok {
+		t.Error("expected Front on an empty deque to fail")
+	}
+}
+
+func TestDequeAtOutOfRange(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	NewDeque(1).At(1)
+}
+
+func BenchmarkDequeQueue(b *testing.B) {
+	d := NewDeque[int]()
+	for i := 0; i < b.N; i++ {
+		d.PushBack(i)
+		if 64 < d.Len() {
+			d.PopFront()
+		}
+	}
+}
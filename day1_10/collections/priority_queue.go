@@ -0,0 +1,74 @@
+package collections
+
+// PriorityQueue は less で最も小さい要素から順に取り出せるキュー. 二分ヒープで持つ
+type PriorityQueue[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewPriorityQueue は less(a, b) が true なら a を b より先に取り出すキューを作る
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+func (q *PriorityQueue[T]) Len() int {
+	return len(q.items)
+}
+
+func (q *PriorityQueue[T]) Push(item T) {
+	q.items = append(q.items, item)
+
+	// 親より優先度が高い間は上へ
+	idx := len(q.items) - 1
+	for 0 < idx {
+		parent := (idx - 1) / 2
+		if !q.less(q.items[idx], q.items[parent]) {
+			break
+		}
+		q.items[idx], q.items[parent] = q.items[parent], q.items[idx]
+		idx = parent
+	}
+}
+
+// Pop は最も優先度の高い要素を取り出す. 空なら false
+func (q *PriorityQueue[T]) Pop() (T, bool) {
+	var zero T
+	if len(q.items) == 0 {
+		return zero, false
+	}
+
+	top := q.items[0]
+	last := len(q.items) - 1
+	q.items[0] = q.items[last]
+	q.items[last] = zero
+	q.items = q.items[:last]
+
+	// 子より優先度が低い間は下へ
+	idx := 0
+	for {
+		smallest := idx
+		left, right := idx*2+1, idx*2+2
+		if left < len(q.items) && q.less(q.items[left], q.items[smallest]) {
+			smallest = left
+		}
+		if right < len(q.items) && q.less(q.items[right], q.items[smallest]) {
+			smallest = right
+		}
+		if smallest == idx {
+			break
+		}
+		q.items[idx], q.items[smallest] = q.items[smallest], q.items[idx]
+		idx = smallest
+	}
+
+	return top, true
+}
+
+// Peek は最も優先度の高い要素を取り出さずに返す. 空なら false
+func (q *PriorityQueue[T]) Peek() (T, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.items[0], true
+}
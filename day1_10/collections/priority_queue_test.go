@@ -0,0 +1,68 @@
+package collections
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	q := NewPriorityQueue(func(a, b int) bool { return a < b })
+
+	rng := rand.New(rand.NewSource(1))
+	values := []int{}
+	for i := 0; i < 200; i++ {
+		value := rng.Intn(50)
+		values = append(values, value)
+		q.Push(value)
+	}
+	sort.Ints(values)
+
+	if top, ok := q.Peek(); !ok || top != values[0] {
+		t.Errorf("Peek: got %d, %t, want %d", top, ok, values[0])
+	}
+
+	for idx, want := range values {
+		got, ok := q.Pop()
+		if !ok || got != want {
+			t.Fatalf("pop %d: got %d, %t, want %d", idx, got, ok, want)
+		}
+	}
+
+	if _, ok := q.Pop(); ok {
+		t.Error("expected Pop on an empty queue to fail")
+	}
+	if _, ok := q.Peek(); ok {
+		t.Error("expected Peek on an empty queue to fail")
+	}
+}
+
+func TestPriorityQueueMaxHeap(t *testing.T) {
+	type job struct {
+		name     string
+		priority int
+	}
+
+	q := NewPriorityQueue(func(a, b job) bool { return a.priority > b.priority })
+	q.Push(job{"low", 1})
+	q.Push(job{"high", 9})
+	q.Push(job{"mid", 5})
+
+	for _, want := range []string{"high", "mid", "low"} {
+		got, _ := q.Pop()
+		if got.name != want {
+			t.Errorf("got %s, want %s", got.name, want)
+		}
+	}
+}
+
+func BenchmarkPriorityQueue(b *testing.B) {
+	q := NewPriorityQueue(func(a, b int) bool { return a < b })
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		q.Push(rng.Int())
+		if 1024 < q.Len() {
+			q.Pop()
+		}
+	}
+}
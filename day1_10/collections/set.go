@@ -0,0 +1,57 @@
+package collections
+
+/*
+collections
+
+型パラメータで要素の型を指定できるコレクション.
+
+* Set: 重複のない要素の集まり
+* Stack: 後入れ先出し
+* Deque: 両端から出し入れできるキュー
+* PriorityQueue: 優先度の高い順に取り出せるキュー
+*/
+
+// Set は重複のない要素の集まり. 要素の順番は決まっていない
+type Set[T comparable] map[T]struct{}
+
+func NewSet[T comparable](items ...T) Set[T] {
+	s := make(Set[T], len(items))
+	s.Add(items...)
+	return s
+}
+
+func (s Set[T]) Add(items ...T) {
+	for _, item := range items {
+		s[item] = struct{}{}
+	}
+}
+
+func (s Set[T]) Remove(items ...T) {
+	for _, item := range items {
+		delete(s, item)
+	}
+}
+
+func (s Set[T]) Contains(item T) bool {
+	_, exists := s[item]
+	return exists
+}
+
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+func (s Set[T]) Clear() {
+	for item := range s {
+		delete(s, item)
+	}
+}
+
+// Values は全ての要素を返す. 順番は決まっていない
+func (s Set[T]) Values() []T {
+	values := make([]T, 0, len(s))
+	for item := range s {
+		values = append(values, item)
+	}
+	return values
+}
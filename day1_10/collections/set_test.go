@@ -0,0 +1,59 @@
+package collections
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	s := NewSet("a", "b", "a")
+	if s.Len() != 2 {
+		t.Fatalf("got len %d, want 2", s.Len())
+	}
+
+	s.Add("c")
+	s.Remove("a", "z")
+	if s.Contains("a") || !s.Contains("b") || !s.Contains("c") {
+		t.Errorf("unexpected contents: %v", s.Values())
+	}
+
+	values := s.Values()
+	sort.Strings(values)
+	if len(values) != 2 || values[0] != "b" || values[1] != "c" {
+		t.Errorf("got %v", values)
+	}
+
+	s.Clear()
+	if s.Len() != 0 || s.Contains("b") {
+		t.Errorf("expected an empty set, got %v", s.Values())
+	}
+}
+
+func TestSetStructKey(t *testing.T) {
+	type point struct{ x, y int }
+
+	s := NewSet[point]()
+	s.Add(point{1, 2}, point{1, 2}, point{2, 1})
+	if s.Len() != 2 || !s.Contains(point{2, 1}) {
+		t.Errorf("unexpected contents: %v", s.Values())
+	}
+}
+
+func BenchmarkSetAdd(b *testing.B) {
+	s := NewSet[int]()
+	for i := 0; i < b.N; i++ {
+		s.Add(i % 1024)
+	}
+}
+
+func BenchmarkSetContains(b *testing.B) {
+	s := NewSet[int]()
+	for i := 0; i < 1024; i++ {
+		s.Add(i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(i % 2048)
+	}
+}
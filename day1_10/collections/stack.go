@@ -0,0 +1,40 @@
+package collections
+
+// Stack は後入れ先出しのコレクション. ゼロ値は空の Stack
+type Stack[T any] struct {
+	items []T
+}
+
+func NewStack[T any](items ...T) *Stack[T] {
+	return &Stack[T]{items: append([]T{}, items...)}
+}
+
+func (s *Stack[T]) Push(item T) {
+	s.items = append(s.items, item)
+}
+
+// Pop は最後に積んだ要素を取り出す. 空なら false
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	if len(s.items) == 0 {
+		return zero, false
+	}
+
+	item := s.items[len(s.items)-1]
+	s.items[len(s.items)-1] = zero
+	s.items = s.items[:len(s.items)-1]
+	return item, true
+}
+
+// Peek は最後に積んだ要素を取り出さずに返す. 空なら false
+func (s *Stack[T]) Peek() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
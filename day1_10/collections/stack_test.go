@@ -0,0 +1,50 @@
+package collections
+
+import (
+	"testing"
+)
+
+func TestStack(t *testing.T) {
+	s := NewStack('Z', 'N')
+	s.Push('D')
+
+	if top, ok := s.Peek(); !ok || top != 'D' {
+		t.Errorf("got %c, %t", top, ok)
+	}
+
+	for _, want := range []rune{'D', 'N', 'Z'} {
+		got, ok := s.Pop()
+		if !ok || got != want {
+			t.Errorf("got %c, %t, want %c", got, ok, want)
+		}
+	}
+
+	if s.Len() != 0 {
+		t.Errorf("got len %d, want 0", s.Len())
+	}
+	if _, ok := s.Pop(); ok {
+		t.Error("expected Pop on an empty stack to fail")
+	}
+	if _, ok := s.Peek(); ok {
+		t.Error("expected Peek on an empty stack to fail")
+	}
+}
+
+func TestStackZeroValue(t *testing.T) {
+	s := Stack[int]{}
+	s.Push(1)
+	if got, ok := s.Pop(); !ok || got != 1 {
+		t.Errorf("got %d, %t", got, ok)
+	}
+}
+
+func BenchmarkStackPushPop(b *testing.B) {
+	s := NewStack[int]()
+	for i := 0; i < b.N; i++ {
+		s.Push(i)
+		if i%2 == 1 {
+			s.Pop()
+			s.Pop()
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+/*
+config
+
+aoc コマンドの設定ファイル (JSON).
+ファイルがなければ全ての項目が既定値になる.
+
+	{
+		"session": "53616c74...",
+		"base_url": "https://adventofcode.com",
+		"params": {"day1.top": 3, "day9.rope": 10}
+	}
+*/
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SessionEnv があれば設定ファイルの session より優先する
+const SessionEnv = "AOC_SESSION"
+
+// Config は設定ファイルの内容
+type Config struct {
+	// adventofcode.com にログインしたときの session cookie の値
+	Session string `json:"session,omitempty"`
+	// 入力の取得先. 空なら site.DefaultBaseURL
+	BaseURL string `json:"base_url,omitempty"`
+	// 各日のパラメータ. キーは params で宣言したもの
+	Params map[string]json.Number `json:"params,omitempty"`
+}
+
+// DefaultPath はユーザーごとの設定ディレクトリにある設定ファイルのパスを返す.
+// 入力と違ってリポジトリの外に置き, session を commit しないようにする
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "aoc2022", "config.json")
+}
+
+// Load は path の設定を読み, 環境変数を反映して返す. path が空かファイルがなければ既定値を使う
+func Load(path string) (Config, error) {
+	config := Config{}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return Config{}, err
+		default:
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&config); err != nil {
+				return Config{}, fmt.Errorf("%s: %w", path, err)
+			}
+		}
+	}
+
+	if session := os.Getenv(SessionEnv); session != "" {
+		config.Session = session
+	}
+	return config, nil
+}
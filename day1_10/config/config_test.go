@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func write(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv(SessionEnv, "")
+
+	config, err := Load(write(t, `{"session": "abc", "base_url": "http://localhost:8080"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Session != "abc" || config.BaseURL != "http://localhost:8080" {
+		t.Errorf("got %+v", config)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	t.Setenv(SessionEnv, "")
+
+	config, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Session != "" || config.BaseURL != "" || config.Params != nil {
+		t.Errorf("got %+v, want defaults", config)
+	}
+}
+
+func TestLoadSessionFromEnv(t *testing.T) {
+	t.Setenv(SessionEnv, "from-env")
+
+	config, err := Load(write(t, `{"session": "from-file"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Session != "from-env" {
+		t.Errorf("got session %q, want from-env", config.Session)
+	}
+}
+
+func TestLoadParams(t *testing.T) {
+	config, err := Load(write(t, `{"params": {"day1.top": 5, "day9.rope": "12"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Params["day1.top"] != "5" || config.Params["day9.rope"] != "12" {
+		t.Errorf("got %v", config.Params)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	for _, content := range []string{`{"session": 1}`, `{"sesion": "typo"}`, `{`, `{"params": {"day1.top": "three"}}`} {
+		if _, err := Load(write(t, content)); err == nil {
+			t.Errorf("%s: expected an error", content)
+		}
+	}
+}
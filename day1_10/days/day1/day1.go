@@ -10,84 +10,79 @@ day1
 
 Q1. 最大のカロリーを持つエルフの総カロリー数は?
 Q2. 最大カロリーTOP3のエルフが運ぶ総カロリー数の合計は?
+
+* 上位何人分を足すかは day1.top で変えられる
 */
 
 import (
-	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"math"
-	"os"
 	"sort"
-	"strconv"
-)
 
-func PartOne() {
-	sc := bufio.NewScanner(os.Stdin)
-
-	maxCalories := 0
-	totalCalories := 0
+	"Aoc2022/input"
+	"Aoc2022/params"
+	"Aoc2022/solver"
+)
 
-	for {
-		out, err := scanInt(sc)
+// Part2 で足し合わせる上位のエルフの人数
+var topCount = params.NewInt("day1.top", 3, 1, math.MaxInt, "number of top elves whose calories are summed in part two")
 
-		if err == nil {
-			totalCalories += out
-			continue
-		}
+// 空行区切りで, エルフごとの総カロリーを読む
+func scanTotalCalories(r io.Reader) ([]int, error) {
+	groups, err := input.Groups(r)
+	if err != nil {
+		return nil, err
+	}
 
-		// 入力が何もない時
-		if totalCalories == 0 {
-			break
+	totalCaloriesTable := []int{}
+	for _, group := range groups {
+		totalCalories := 0
+		for _, line := range group {
+			calories, err := line.Int()
+			if err != nil {
+				return nil, err
+			}
+			totalCalories += calories
 		}
-
-		// 1人分のカロリーの入力が終わった時
-		maxCalories = int(math.Max(float64(maxCalories), float64(totalCalories)))
-		totalCalories = 0
+		totalCaloriesTable = append(totalCaloriesTable, totalCalories)
 	}
 
-	fmt.Println(maxCalories)
+	return totalCaloriesTable, nil
 }
 
-func PartTwo() {
-	sc := bufio.NewScanner(os.Stdin)
-
-	totalCalories := 0
-	totalCaloriesTable := []int{}
+func PartOne(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	totalCaloriesTable, err := scanTotalCalories(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
-	for {
-		out, err := scanInt(sc)
+	maxCalories := 0
+	for _, totalCalories := range totalCaloriesTable {
+		maxCalories = int(math.Max(float64(maxCalories), float64(totalCalories)))
+	}
 
-		if err == nil {
-			totalCalories += out
-			continue
-		}
+	return solver.Int(maxCalories), nil
+}
 
-		// 入力が何もない時
-		if totalCalories == 0 {
-			totalCaloriesTable = append(totalCaloriesTable, totalCalories)
-			break
-		}
+func PartTwo(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	totalCaloriesTable, err := scanTotalCalories(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
-		// 1人分のカロリーの入力が終わった時
-		totalCaloriesTable = append(totalCaloriesTable, totalCalories)
-		totalCalories = 0
+	top := topCount.Get(ctx)
+	if len(totalCaloriesTable) < top {
+		return solver.Answer{}, fmt.Errorf("need at least %d elves", top)
 	}
 
 	// 降順ソート
 	sort.Slice(totalCaloriesTable, func(i, j int) bool { return totalCaloriesTable[j] < totalCaloriesTable[i] })
 	answer := 0
-	for i := 0; i < 3; i++ {
+	for i := 0; i < top; i++ {
 		answer += totalCaloriesTable[i]
 	}
-}
 
-func scanInt(sc *bufio.Scanner) (int, error) {
-	// 読みこみ
-	sc.Scan()
-	// int に変換
-	i, err := strconv.Atoi(sc.Text())
-	if err != nil {
-		return 0, err
-	}
-	return i, nil
+	return solver.Int(answer), nil
 }
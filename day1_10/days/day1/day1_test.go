@@ -0,0 +1,41 @@
+package day1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"Aoc2022/params"
+	"Aoc2022/solver"
+	"Aoc2022/solver/solvertest"
+)
+
+func TestPartOne(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, PartOne, input, solver.Int(24000))
+}
+
+func TestPartTwo(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, PartTwo, input, solver.Int(45000))
+}
+
+func TestExtraBlankLines(t *testing.T) {
+	solvertest.Check(t, PartOne, "\n1000\n\n\n\n2000\n3000\n\n", solver.Int(5000))
+}
+
+func TestInvalidCalories(t *testing.T) {
+	solvertest.CheckError(t, PartOne, "1000\n\n2x00\n", 3)
+	solvertest.CheckError(t, PartTwo, "1000\n\n2000\n\n3000\n4000\n-\n", 7)
+}
+
+func TestParams(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, solvertest.WithParams(PartTwo, params.Values{"day1.top": 1}), input, solver.Int(24000))
+	solvertest.Check(t, solvertest.WithParams(PartTwo, params.Values{"day1.top": 5}), input, solver.Int(55000))
+
+	// 例のエルフは 5 人
+	if _, err := solvertest.WithParams(PartTwo, params.Values{"day1.top": 6})(context.Background(), strings.NewReader(input)); err == nil {
+		t.Error("expected an error for more elves than there are")
+	}
+}
@@ -49,16 +49,48 @@ Display :   ##..#
 
 といった具合.
 コマンドを処理していった結果、CRTに表示される8つの大文字は何?
+
+* Q1 の 20, 40, 220 は day10.first, day10.step, day10.last で,
+  CRT の大きさは day10.width, day10.height で変えられる
 */
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"math"
 	"strconv"
 	"strings"
+
+	"Aoc2022/grid"
+	"Aoc2022/input"
+	"Aoc2022/params"
+	"Aoc2022/solver"
+	"Aoc2022/tracing"
 )
 
+var tracer = tracing.New("day10")
+
+var (
+	// Part1 で信号の強さを測るサイクル. first から step ごとに last まで
+	firstProbe = params.NewInt("day10.first", 20, 1, math.MaxInt, "first cycle whose signal strength is summed in part one")
+	probeStep  = params.NewInt("day10.step", 40, 1, math.MaxInt, "cycles between signal strength probes in part one")
+	lastProbe  = params.NewInt("day10.last", 220, 1, math.MaxInt, "last cycle probed in part one")
+	// Part2 の CRT の大きさ
+	crtWidth  = params.NewInt("day10.width", 40, 1, 1000, "CRT width in pixels (part two)")
+	crtHeight = params.NewInt("day10.height", 6, 1, 1000, "CRT height in pixels (part two)")
+)
+
+func init() {
+	params.AddRule(func(values params.Values) error {
+		if values.Int(lastProbe) < values.Int(firstProbe) {
+			return fmt.Errorf("%s (%d) must not be less than %s (%d)", lastProbe.Key, values.Int(lastProbe), firstProbe.Key, values.Int(firstProbe))
+		}
+		return nil
+	})
+}
+
 type Command int
 
 const (
@@ -66,12 +98,7 @@ const (
 	Addx
 )
 
-func scanLine(sc *bufio.Scanner) string {
-	sc.Scan()
-	return sc.Text()
-}
-
-func ParseCommand(command string) (Command, int, int) {
+func ParseCommand(command string) (Command, int, int, error) {
 	elements := strings.Split(command, " ")
 
 	var commandType Command = Noop
@@ -80,15 +107,27 @@ func ParseCommand(command string) (Command, int, int) {
 
 	switch elements[0] {
 	case "addx":
+		if len(elements) != 2 {
+			return Noop, 0, 0, errors.New("addx takes exactly 1 value")
+		}
 		commandType = Addx
 		duration = 2
-		value, _ = strconv.Atoi(elements[1])
+		parsed, err := strconv.Atoi(elements[1])
+		if err != nil {
+			return Noop, 0, 0, err
+		}
+		value = parsed
 	case "noop":
+		if len(elements) != 1 {
+			return Noop, 0, 0, errors.New("noop takes no value")
+		}
 		commandType = Noop
 		duration = 1
+	default:
+		return Noop, 0, 0, errors.New("unknown instruction " + elements[0])
 	}
 
-	return commandType, duration, value
+	return commandType, duration, value, nil
 }
 
 func calcStrength(value int, cycle int) int {
@@ -107,28 +146,34 @@ func willShowPixel(position int, spriteCenter int, width int) bool {
 	return horizontalSpriteCenter-1 <= horizontalPosition && horizontalPosition <= horizontalSpriteCenter+1
 }
 
-func PartOne() {
+func PartOne(ctx context.Context, r io.Reader) (solver.Answer, error) {
 
-	scanner := bufio.NewScanner(os.Stdin)
+	lines, err := input.Lines(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
 	X := 1
 	cycle := 0
-	batchCount := 40
-	strengthFootprint := make([]int, 0)
-
-	for {
-		line := scanLine(scanner)
+	next, step, last := firstProbe.Get(ctx), probeStep.Get(ctx), lastProbe.Get(ctx)
+	result := 0
 
-		if len(line) == 0 {
-			break
+	for _, line := range lines {
+		command, duration, value, err := ParseCommand(line.Text)
+		if err != nil {
+			return solver.Answer{}, line.Wrap(err)
 		}
-
-		command, duration, value := ParseCommand(line)
 		for count := 0; count < duration; count++ {
+			if err := solver.Poll(ctx, cycle); err != nil {
+				return solver.Answer{}, err
+			}
 			cycle++
-			if cycle%20 == 0 {
-				//fmt.Printf("cycle: %d, value: %d \n", cycle, calcStrength(X, cycle))
-				strengthFootprint = append(strengthFootprint, calcStrength(X, cycle))
+			// 命令の途中のサイクルでは, まだ加算前の X で測る
+			if cycle == next && next <= last {
+				result += calcStrength(X, cycle)
+				tracer.Printf(tracing.Debug, "cycle: %d, X: %d, strength: %d", cycle, X, calcStrength(X, cycle))
+				tracer.Printf(tracing.Info, "cycle %d: running total %d", cycle, result)
+				next += step
 			}
 		}
 
@@ -137,42 +182,42 @@ func PartOne() {
 		}
 	}
 
-	result := 0
-	for cycleCount := 20; cycleCount <= 220; cycleCount += batchCount {
-		idx := cycleCount/20 - 1
-		result += strengthFootprint[idx]
-		fmt.Println(result)
+	if next <= last {
+		return solver.Answer{}, errors.New("program ended before cycle " + strconv.Itoa(next))
 	}
 
-	fmt.Println(result)
+	return solver.Int(result), nil
 }
 
-func PartTwo() {
+func PartTwo(ctx context.Context, r io.Reader) (solver.Answer, error) {
 
-	scanner := bufio.NewScanner(os.Stdin)
+	lines, err := input.Lines(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
 	X := 1
 	cycle := 0
-	const width = 40
-	const height = 6
-	display := [height][width]rune{}
-
-	for {
-		line := scanLine(scanner)
-
-		if len(line) == 0 {
-			break
+	width, height := crtWidth.Get(ctx), crtHeight.Get(ctx)
+	display := grid.New[rune](width, height)
+	display.Fill('.')
+
+	for _, line := range lines {
+		command, duration, value, err := ParseCommand(line.Text)
+		if err != nil {
+			return solver.Answer{}, line.Wrap(err)
 		}
-
-		command, duration, value := ParseCommand(line)
 		for count := 0; count < duration; count++ {
+			if err := solver.Poll(ctx, cycle); err != nil {
+				return solver.Answer{}, err
+			}
 
 			x, y := calcPosition(cycle, width)
-			//fmt.Printf("x: %d, y: %d, cycle: %d, X:%d \n", x, y, cycle, X)
-			if willShowPixel(cycle, X, width) {
-				display[y][x] = '#'
-			} else {
-				display[y][x] = '.'
+			tracer.Printf(tracing.Verbose, "x: %d, y: %d, cycle: %d, X: %d", x, y, cycle, X)
+			pixel := grid.Point{X: x, Y: y}
+			// 画面を描き終えた後のサイクルは表示されない
+			if display.In(pixel) && willShowPixel(cycle, X, width) {
+				display.Set(pixel, '#')
 			}
 
 			cycle++
@@ -183,10 +228,5 @@ func PartTwo() {
 		}
 	}
 
-	for y := 0; y < height; y++ {
-		for x := 0; x < width; x++ {
-			fmt.Printf("%c", display[y][x])
-		}
-		fmt.Printf("\n")
-	}
+	return solver.Grid(display.Render(func(_ grid.Point, pixel rune) rune { return pixel })), nil
 }
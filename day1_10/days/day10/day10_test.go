@@ -0,0 +1,60 @@
+package day10
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"Aoc2022/params"
+	"Aoc2022/solver"
+	"Aoc2022/solver/solvertest"
+)
+
+func TestPartOne(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, PartOne, input, solver.Int(13140))
+}
+
+func TestPartTwo(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, PartTwo, input, solver.Grid([]string{
+		"##..##..##..##..##..##..##..##..##..##..",
+		"###...###...###...###...###...###...###.",
+		"####....####....####....####....####....",
+		"#####.....#####.....#####.....#####.....",
+		"######......######......######......####",
+		"#######.......#######.......#######.....",
+	}))
+}
+
+func TestInvalidInstruction(t *testing.T) {
+	solvertest.CheckError(t, PartOne, "noop\naddx x\n", 2)
+	solvertest.CheckError(t, PartTwo, "noop\nmul 3\n", 2)
+}
+
+func TestParams(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, solvertest.WithParams(PartOne, params.Values{"day10.last": 20}), input, solver.Int(420))
+	solvertest.Check(t, solvertest.WithParams(PartOne, params.Values{"day10.first": 1, "day10.step": 1, "day10.last": 3}), input, solver.Int(1+2+16*3))
+	solvertest.Check(t, solvertest.WithParams(PartTwo, params.Values{"day10.width": 20, "day10.height": 1}), input, solver.Grid([]string{"##..##..##..##..##.."}))
+
+	_, err := solvertest.WithParams(PartOne, params.Values{"day10.first": 260, "day10.last": 300})(context.Background(), strings.NewReader(input))
+	if err == nil {
+		t.Error("expected an error for a probe after the program ends")
+	}
+}
+
+func FuzzParseCommand(f *testing.F) {
+	for _, seed := range []string{"noop", "addx 3", "addx -5", "mul 3"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, command string) {
+		commandType, duration, _, err := ParseCommand(command)
+		if err != nil {
+			return
+		}
+		if (commandType == Addx && duration != 2) || (commandType == Noop && duration != 1) {
+			t.Errorf("ParseCommand(%q) = %v with duration %d", command, commandType, duration)
+		}
+	})
+}
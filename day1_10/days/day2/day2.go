@@ -29,36 +29,40 @@ Q2.
 */
 
 import (
-	"bufio"
-	"fmt"
-	"os"
+	"context"
+	"errors"
+	"io"
 	"strings"
+
+	"Aoc2022/input"
+	"Aoc2022/solver"
 )
 
-func scanRound(sc *bufio.Scanner) (string, string, bool) {
-	sc.Scan()
+func parseRound(line string) (string, string, error) {
 
-	str := sc.Text()
+	hands := strings.Fields(line)
+	if len(hands) != 2 {
+		return "", "", errors.New("expected 2 hands")
+	}
 
-	hands := strings.Split(str, " ")
-	if len(hands) < 2 {
-		return "", "", false
+	if !strings.Contains("ABC", hands[0]) || len(hands[0]) != 1 {
+		return "", "", errors.New("unknown opponent hand " + hands[0])
+	}
+	if !strings.Contains("XYZ", hands[1]) || len(hands[1]) != 1 {
+		return "", "", errors.New("unknown strategy " + hands[1])
 	}
 
-	return hands[0], hands[1], true
+	return hands[0], hands[1], nil
 }
 
 func getHandScore(self string) int {
 
 	switch self {
-	case "X":
-	case "a":
+	case "X", "a":
 		return 1
-	case "Y":
-	case "b":
+	case "Y", "b":
 		return 2
-	case "Z":
-	case "c":
+	case "Z", "c":
 		return 3
 	}
 
@@ -70,40 +74,31 @@ func getResultScore(opponent string, self string) int {
 	switch opponent {
 	case "A":
 		switch self {
-		case "X":
-		case "a":
+		case "X", "a":
 			return 3
-		case "Y":
-		case "b":
+		case "Y", "b":
 			return 6
-		case "Z":
-		case "c":
+		case "Z", "c":
 			return 0
 		}
 
 	case "B":
 		switch self {
-		case "X":
-		case "a":
+		case "X", "a":
 			return 0
-		case "Y":
-		case "b":
+		case "Y", "b":
 			return 3
-		case "Z":
-		case "c":
+		case "Z", "c":
 			return 6
 		}
 
 	case "C":
 		switch self {
-		case "X":
-		case "a":
+		case "X", "a":
 			return 6
-		case "Y":
-		case "b":
+		case "Y", "b":
 			return 0
-		case "Z":
-		case "c":
+		case "Z", "c":
 			return 3
 		}
 	}
@@ -170,38 +165,42 @@ func getGuessedScore(opponent string, roundResult string) int {
 	return getHandScore(self) + getResultScore(opponent, self)
 }
 
-func PartOne() {
+func PartOne(ctx context.Context, r io.Reader) (solver.Answer, error) {
 
-	sc := bufio.NewScanner(os.Stdin)
-	result := 0
-
-	for {
-		opponent, self, success := scanRound(sc)
+	lines, err := input.Lines(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
-		if !success {
-			break
+	result := 0
+	for _, line := range lines {
+		opponent, self, err := parseRound(line.Text)
+		if err != nil {
+			return solver.Answer{}, line.Wrap(err)
 		}
 
 		result += getScore(opponent, self)
 	}
 
-	fmt.Println(result)
+	return solver.Int(result), nil
 }
 
-func PartTwo() {
-
-	sc := bufio.NewScanner(os.Stdin)
-	result := 0
+func PartTwo(ctx context.Context, r io.Reader) (solver.Answer, error) {
 
-	for {
-		opponent, round, success := scanRound(sc)
+	lines, err := input.Lines(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
-		if !success {
-			break
+	result := 0
+	for _, line := range lines {
+		opponent, round, err := parseRound(line.Text)
+		if err != nil {
+			return solver.Answer{}, line.Wrap(err)
 		}
 
 		result += getGuessedScore(opponent, round)
 	}
 
-	fmt.Println(result)
+	return solver.Int(result), nil
 }
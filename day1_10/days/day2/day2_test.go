@@ -0,0 +1,18 @@
+package day2
+
+import (
+	"testing"
+
+	"Aoc2022/solver"
+	"Aoc2022/solver/solvertest"
+)
+
+func TestPartOne(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, PartOne, input, solver.Int(15))
+}
+
+func TestPartTwo(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, PartTwo, input, solver.Int(12))
+}
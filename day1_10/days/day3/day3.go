@@ -32,16 +32,27 @@ Q2.
 */
 
 import (
-	"bufio"
-	"fmt"
-	"os"
-
-	"github.com/emirpasic/gods/sets/hashset"
+	"context"
+	"errors"
+	"io"
+
+	"Aoc2022/collections"
+	"Aoc2022/input"
+	"Aoc2022/solver"
+	"Aoc2022/tracing"
 )
 
-func scanLine(sc *bufio.Scanner) string {
-	sc.Scan()
-	return sc.Text()
+var tracer = tracing.New("day3")
+
+// アイテムは a~z, A~Z のみ
+func validateItems(line string) error {
+	for idx := 0; idx < len(line); idx++ {
+		char := line[idx]
+		if !('a' <= char && char <= 'z') && !('A' <= char && char <= 'Z') {
+			return errors.New("invalid item " + string(rune(char)))
+		}
+	}
+	return nil
 }
 
 func calcPriority(char rune) int {
@@ -54,23 +65,29 @@ func calcPriority(char rune) int {
 	return (int)(char - 'A' + 27)
 }
 
-func PartOne() {
-	scanner := bufio.NewScanner(os.Stdin)
+func PartOne(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
 	prioritySum := 0
 
-	for {
-		line := scanLine(scanner)
+	for _, rucksack := range lines {
+		line := rucksack.Text
 		lineLen := len(line)
 
-		if lineLen == 0 {
-			break
+		if err := validateItems(line); err != nil {
+			return solver.Answer{}, rucksack.Wrap(err)
+		}
+		if lineLen%2 != 0 {
+			return solver.Answer{}, rucksack.Errorf("compartments must have the same number of items")
 		}
 
 		firstHalf := line[:lineLen/2]
 		latterHalf := line[lineLen/2:]
-		firstRunePattern := hashset.New()
-		latterRunePattern := hashset.New()
+		firstRunePattern := collections.NewSet[byte]()
+		latterRunePattern := collections.NewSet[byte]()
 
 		for idx := 0; idx < lineLen/2; idx++ {
 			firstRunePattern.Add(firstHalf[idx])
@@ -82,48 +99,56 @@ func PartOne() {
 				priority := calcPriority((rune)(char))
 				prioritySum += priority
 				latterRunePattern.Add(char)
-				//fmt.Println(priority)
+				tracer.Printf(tracing.Debug, "line %d: %c has priority %d", rucksack.Number, char, priority)
 			}
 		}
 	}
 
-	fmt.Println(prioritySum)
+	return solver.Int(prioritySum), nil
 }
 
-func PartTwo() {
+func PartTwo(ctx context.Context, r io.Reader) (solver.Answer, error) {
 
-	scanner := bufio.NewScanner(os.Stdin)
+	lines, err := input.Lines(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
-	prioritySum := 0
+	for _, rucksack := range lines {
+		if err := validateItems(rucksack.Text); err != nil {
+			return solver.Answer{}, rucksack.Wrap(err)
+		}
+	}
+	if len(lines)%3 != 0 {
+		return solver.Answer{}, lines[len(lines)-1].Errorf("group of 3 elves is incomplete")
+	}
 
-	for {
-		firstRunePattern := hashset.New()
-		secondRunePattern := hashset.New()
+	prioritySum := 0
 
-		line := scanLine(scanner)
-		if len(line) == 0 {
-			break
-		}
+	for group := 0; group < len(lines); group += 3 {
+		firstRunePattern := collections.NewSet[byte]()
+		secondRunePattern := collections.NewSet[byte]()
 
+		line := lines[group].Text
 		for idx := 0; idx < len(line); idx++ {
 			firstRunePattern.Add(line[idx])
 		}
 
-		secondLine := scanLine(scanner)
+		secondLine := lines[group+1].Text
 		for idx := 0; idx < len(secondLine); idx++ {
 			secondRunePattern.Add(secondLine[idx])
 		}
 
-		thirdLine := scanLine(scanner)
+		thirdLine := lines[group+2].Text
 		for idx := 0; idx < len(thirdLine); idx++ {
 			if firstRunePattern.Contains(thirdLine[idx]) &&
 				secondRunePattern.Contains(thirdLine[idx]) {
-				//fmt.Println(calcPriority(rune(thirdLine[idx])))
+				tracer.Printf(tracing.Debug, "group %d: badge %c has priority %d", group/3+1, thirdLine[idx], calcPriority(rune(thirdLine[idx])))
 				prioritySum += calcPriority(rune(thirdLine[idx]))
 				break
 			}
 		}
 	}
 
-	fmt.Println(prioritySum)
+	return solver.Int(prioritySum), nil
 }
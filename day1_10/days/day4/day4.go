@@ -23,119 +23,89 @@ Q2. エルフはオーバーラップするペアの数を知りたい.
 */
 
 import (
-	"bufio"
-	"fmt"
-	"os"
+	"context"
+	"errors"
+	"io"
 	"strconv"
 	"strings"
+
+	"Aoc2022/input"
+	"Aoc2022/solver"
+	"Aoc2022/tracing"
 )
 
-func scanLine(sc *bufio.Scanner) string {
-	sc.Scan()
-	return sc.Text()
-}
+var tracer = tracing.New("day4")
 
-func translateToPair(line string) (int, int, int, int, bool) {
+func translateToPair(line string) (int, int, int, int, error) {
 
 	pair := strings.Split(line, ",")
 
 	if len(pair) != 2 {
-		return 0, 0, 0, 0, false
+		return 0, 0, 0, 0, errors.New("expected 2 sections separated by ,")
 	}
 
-	firstSegment := strings.Split(pair[0], "-")
-	firstHalfStart, _ := strconv.Atoi(firstSegment[0])
-	firstHalfEnd, _ := strconv.Atoi(firstSegment[1])
-
-	latterSegment := strings.Split(pair[1], "-")
-	latterHalfStart, _ := strconv.Atoi(latterSegment[0])
-	latterHalfEnd, _ := strconv.Atoi(latterSegment[1])
-
-	return firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd, true
-}
-
-func isFullyOverlap(firstHalfStart int, firstHalfEnd int, latterHalfStart int, latterHalfEnd int) bool {
-
-	if firstHalfStart < latterHalfStart || latterHalfEnd < firstHalfStart {
-		return false
+	sections := make([]int, 0, 4)
+	for _, segment := range pair {
+		bounds := strings.Split(segment, "-")
+		if len(bounds) != 2 {
+			return 0, 0, 0, 0, errors.New("expected a section range like 2-4, got " + strconv.Quote(segment))
+		}
+		for _, value := range bounds {
+			section, err := strconv.Atoi(value)
+			if err != nil {
+				return 0, 0, 0, 0, err
+			}
+			sections = append(sections, section)
+		}
+		if sections[len(sections)-1] < sections[len(sections)-2] {
+			return 0, 0, 0, 0, errors.New("section range " + segment + " ends before it starts")
+		}
 	}
 
-	if firstHalfEnd < latterHalfStart || latterHalfEnd < firstHalfEnd {
-		return false
-	}
+	return sections[0], sections[1], sections[2], sections[3], nil
+}
 
-	return true
+// 片方の区間がもう片方を含む
+func contains(firstStart int, firstEnd int, latterStart int, latterEnd int) bool {
+	return (firstStart <= latterStart && latterEnd <= firstEnd) ||
+		(latterStart <= firstStart && firstEnd <= latterEnd)
 }
 
-func isOverlap(firstHalfStart int, firstHalfEnd int, latterHalfStart int, latterHalfEnd int) bool {
+// 2 つの区間が 1 つでも同じセクションを含む
+func overlaps(firstStart int, firstEnd int, latterStart int, latterEnd int) bool {
+	return firstStart <= latterEnd && latterStart <= firstEnd
+}
 
-	if latterHalfStart <= firstHalfStart && firstHalfStart <= latterHalfEnd {
-		return true
-	}
+// 各行のペアのうち matches を満たすものを数える
+func countPairs(r io.Reader, matches func(int, int, int, int) bool) (solver.Answer, error) {
 
-	if latterHalfStart <= firstHalfEnd && firstHalfEnd <= latterHalfEnd {
-		return true
+	lines, err := input.Lines(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
-	return false
-}
-
-func PartOne() {
-
-	scanner := bufio.NewScanner(os.Stdin)
 	result := 0
 
-	for {
-
-		line := scanLine(scanner)
-		firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd, success := translateToPair(line)
+	for _, line := range lines {
 
-		if !success {
-			break
+		firstStart, firstEnd, latterStart, latterEnd, err := translateToPair(line.Text)
+		if err != nil {
+			return solver.Answer{}, line.Wrap(err)
 		}
 
-		if isFullyOverlap(firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd) {
-			//fmt.Print(firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd)
+		if matches(firstStart, firstEnd, latterStart, latterEnd) {
+			tracer.Printf(tracing.Debug, "line %d: %d-%d,%d-%d", line.Number, firstStart, firstEnd, latterStart, latterEnd)
 			result++
-			continue
-		}
-
-		if isFullyOverlap(latterHalfStart, latterHalfEnd, firstHalfStart, firstHalfEnd) {
-			//fmt.Print(firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd)
-			result++
-			continue
 		}
 	}
 
-	fmt.Println(result)
+	return solver.Int(result), nil
 }
 
-func PartTwo() {
-
-	scanner := bufio.NewScanner(os.Stdin)
-	result := 0
-
-	for {
-
-		line := scanLine(scanner)
-		firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd, success := translateToPair(line)
-
-		if !success {
-			break
-		}
-
-		if isOverlap(firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd) {
-			//fmt.Print(firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd)
-			result++
-			continue
-		}
-
-		if isOverlap(latterHalfStart, latterHalfEnd, firstHalfStart, firstHalfEnd) {
-			//fmt.Print(firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd)
-			result++
-			continue
-		}
-	}
+func PartOne(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	return countPairs(r, contains)
+}
 
-	fmt.Println(result)
+func PartTwo(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	return countPairs(r, overlaps)
 }
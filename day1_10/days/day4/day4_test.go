@@ -0,0 +1,52 @@
+package day4
+
+import (
+	"fmt"
+	"testing"
+
+	"Aoc2022/solver"
+	"Aoc2022/solver/solvertest"
+)
+
+func TestPartOne(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, PartOne, input, solver.Int(2))
+}
+
+func TestPartTwo(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, PartTwo, input, solver.Int(4))
+}
+
+func TestReference(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, ReferencePartOne, input, solver.Int(2))
+	solvertest.Check(t, ReferencePartTwo, input, solver.Int(4))
+}
+
+func TestReversedSection(t *testing.T) {
+	solvertest.CheckError(t, PartOne, "2-4,6-8\n4-2,6-8\n", 2)
+}
+
+func TestInvalidPair(t *testing.T) {
+	solvertest.CheckError(t, PartOne, "2-4,6-8\n2-3,4-x\n", 2)
+	solvertest.CheckError(t, PartTwo, "2-4,6-8\n\n5-7,7-9\n", 2)
+}
+
+func FuzzTranslateToPair(f *testing.F) {
+	for _, seed := range []string{"2-4,6-8", "6-6,4-6", "2-8,3-7", "1-2,3"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, line string) {
+		a, b, c, d, err := translateToPair(line)
+		if err != nil {
+			return
+		}
+		// 読めた値を書き戻しても同じ値になる
+		formatted := fmt.Sprintf("%d-%d,%d-%d", a, b, c, d)
+		a2, b2, c2, d2, err := translateToPair(formatted)
+		if err != nil || a != a2 || b != b2 || c != c2 || d != d2 {
+			t.Errorf("%q parsed as %q, which does not round-trip", line, formatted)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package day4
+
+// 最適化する前の素直な解法. diffcheck で PartOne, PartTwo と答えを比べるために残している
+
+import (
+	"context"
+	"io"
+
+	"Aoc2022/input"
+	"Aoc2022/solver"
+	"Aoc2022/tracing"
+)
+
+func isFullyOverlap(firstHalfStart int, firstHalfEnd int, latterHalfStart int, latterHalfEnd int) bool {
+
+	if firstHalfStart < latterHalfStart || latterHalfEnd < firstHalfStart {
+		return false
+	}
+
+	if firstHalfEnd < latterHalfStart || latterHalfEnd < firstHalfEnd {
+		return false
+	}
+
+	return true
+}
+
+func isOverlap(firstHalfStart int, firstHalfEnd int, latterHalfStart int, latterHalfEnd int) bool {
+
+	if latterHalfStart <= firstHalfStart && firstHalfStart <= latterHalfEnd {
+		return true
+	}
+
+	if latterHalfStart <= firstHalfEnd && firstHalfEnd <= latterHalfEnd {
+		return true
+	}
+
+	return false
+}
+
+func ReferencePartOne(ctx context.Context, r io.Reader) (solver.Answer, error) {
+
+	lines, err := input.Lines(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	result := 0
+
+	for _, line := range lines {
+
+		firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd, err := translateToPair(line.Text)
+
+		if err != nil {
+			return solver.Answer{}, line.Wrap(err)
+		}
+
+		if isFullyOverlap(firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd) {
+			tracer.Printf(tracing.Debug, "line %d: %d-%d,%d-%d", line.Number, firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd)
+			result++
+			continue
+		}
+
+		if isFullyOverlap(latterHalfStart, latterHalfEnd, firstHalfStart, firstHalfEnd) {
+			tracer.Printf(tracing.Debug, "line %d: %d-%d,%d-%d", line.Number, firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd)
+			result++
+			continue
+		}
+	}
+
+	return solver.Int(result), nil
+}
+
+func ReferencePartTwo(ctx context.Context, r io.Reader) (solver.Answer, error) {
+
+	lines, err := input.Lines(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	result := 0
+
+	for _, line := range lines {
+
+		firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd, err := translateToPair(line.Text)
+
+		if err != nil {
+			return solver.Answer{}, line.Wrap(err)
+		}
+
+		if isOverlap(firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd) {
+			tracer.Printf(tracing.Debug, "line %d: %d-%d,%d-%d", line.Number, firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd)
+			result++
+			continue
+		}
+
+		if isOverlap(latterHalfStart, latterHalfEnd, firstHalfStart, firstHalfEnd) {
+			tracer.Printf(tracing.Debug, "line %d: %d-%d,%d-%d", line.Number, firstHalfStart, firstHalfEnd, latterHalfStart, latterHalfEnd)
+			result++
+			continue
+		}
+	}
+
+	return solver.Int(result), nil
+}
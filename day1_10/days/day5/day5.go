@@ -28,49 +28,30 @@ Q2. procedure の操作は実は, move 回分 pop ではなく move こまとめ
 */
 
 import (
-	"bufio"
-	"fmt"
-	"os"
+	"context"
+	"errors"
+	"io"
 	"sort"
 	"strconv"
-	"strings"
 
-	"github.com/golang-collections/collections/stack"
+	"Aoc2022/collections"
+	"Aoc2022/input"
+	"Aoc2022/solver"
+	"Aoc2022/tracing"
 )
 
-func initialState() []string {
-	return []string{
-		"SCVN",
-		"ZMJHNS",
-		"MCTGJND",
-		"TDFJWRM",
-		"PFH",
-		"CTZHJ",
-		"DPRQFSLZ",
-		"CSLHDFPW",
-		"DSMPFNGZ",
-	}
-}
-
-func scanLine(sc *bufio.Scanner) string {
-	sc.Scan()
-	return sc.Text()
-}
+var tracer = tracing.New("day5")
 
-func parseProcedure(line string) (int, int, int, bool) {
-	elements := strings.Split(line, " ")
-	if len(elements) != 6 {
-		return 0, 0, 0, false
+func parseProcedure(line string) (int, int, int, error) {
+	var move, from, to int
+	if err := input.Sscanf(line, "move %d from %d to %d", &move, &from, &to); err != nil {
+		return 0, 0, 0, err
 	}
-
-	move, _ := strconv.Atoi(elements[1])
-	from, _ := strconv.Atoi(elements[3])
-	to, _ := strconv.Atoi(elements[5])
-	return move, from, to, true
+	return move, from, to, nil
 }
 
-func parseStackInitialState(line string) *stack.Stack {
-	stack := stack.New()
+func parseStackInitialState(line string) *collections.Stack[rune] {
+	stack := collections.NewStack[rune]()
 	length := len(line)
 	for idx := 0; idx < length; idx++ {
 		stack.Push((rune)(line[idx]))
@@ -78,75 +59,167 @@ func parseStackInitialState(line string) *stack.Stack {
 	return stack
 }
 
-func PartOne() {
+// 空行までのクレートの図を読んで, 番号ごとの stack にする.
+// 図の最終行は stack の番号で, 各 crate はその番号と同じ列に書かれている
+func scanInitialState(sc *input.Scanner) (map[int]*collections.Stack[rune], error) {
+	drawing := []string{}
+	for sc.Scan() {
+		if sc.Line().IsBlank() {
+			break
+		}
+		drawing = append(drawing, sc.Line().Text)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(drawing) == 0 {
+		return nil, errors.New("crate drawing not found")
+	}
+
+	stacks := map[int]*collections.Stack[rune]{}
+	labelLine := input.Line{Number: len(drawing), Text: drawing[len(drawing)-1]}
+	labels := labelLine.Text
+	crates := drawing[:len(drawing)-1]
+	for column := 0; column < len(labels); column++ {
+		if labels[column] == ' ' {
+			continue
+		}
+
+		// 番号の桁が続く間は同じ stack
+		end := column
+		for end < len(labels) && labels[end] != ' ' {
+			end++
+		}
+		key, err := strconv.Atoi(labels[column:end])
+		if err != nil {
+			return nil, labelLine.Errorf("invalid stack number %s", labels[column:end])
+		}
 
-	stacks := map[int]*stack.Stack{}
-	initialState := initialState()
-	for idx, state := range initialState {
-		key := idx + 1
+		// 下から順に積む
+		state := ""
+		for row := len(crates) - 1; 0 <= row; row-- {
+			if column < len(crates[row]) && crates[row][column] != ' ' {
+				state += string(crates[row][column])
+			}
+		}
 		stacks[key] = parseStackInitialState(state)
+		column = end
 	}
 
-	scanner := bufio.NewScanner(os.Stdin)
-	for {
-		line := scanLine(scanner)
-		move, from, to, success := parseProcedure(line)
-		//fmt.Printf("%d, %d, %d, %t", move, from, to, success)
+	return stacks, nil
+}
 
-		if !success {
-			break
+// 存在する stack から, 積まれている数以下の crate を動かす指示か確かめる
+func checkProcedure(stacks map[int]*collections.Stack[rune], move int, from int, to int) error {
+	if stacks[from] == nil {
+		return errors.New("unknown stack " + strconv.Itoa(from))
+	}
+	if stacks[to] == nil {
+		return errors.New("unknown stack " + strconv.Itoa(to))
+	}
+	if move < 0 || stacks[from].Len() < move {
+		return errors.New("cannot move " + strconv.Itoa(move) + " crates from stack " + strconv.Itoa(from))
+	}
+	return nil
+}
+
+// 各 stack の top の crate を stack の番号順に並べる
+func topCrates(stacks map[int]*collections.Stack[rune]) (solver.Answer, error) {
+	keys := []int{}
+	for key := range stacks {
+		keys = append(keys, key)
+	}
+
+	sort.Ints(keys)
+	result := ""
+	for _, key := range keys {
+		crate, ok := stacks[key].Peek()
+		if !ok {
+			return solver.Answer{}, errors.New("stack " + strconv.Itoa(key) + " is empty")
+		}
+		tracer.Printf(tracing.Info, "stack %d: %d crate(s), %c on top", key, stacks[key].Len(), crate)
+		result += string(crate)
+	}
+
+	return solver.String(result), nil
+}
+
+func PartOne(ctx context.Context, r io.Reader) (solver.Answer, error) {
+
+	scanner := input.NewScanner(r)
+	stacks, err := scanInitialState(scanner)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	for scanner.Scan() {
+		line := scanner.Line()
+		if line.IsBlank() {
+			continue
+		}
+
+		move, from, to, err := parseProcedure(line.Text)
+
+		if err != nil {
+			return solver.Answer{}, line.Wrap(err)
+		}
+		tracer.Printf(tracing.Debug, "move %d from %d to %d", move, from, to)
+
+		if err := checkProcedure(stacks, move, from, to); err != nil {
+			return solver.Answer{}, line.Wrap(err)
 		}
 
 		for count := 0; count < move; count++ {
-			crate := stacks[from].Pop()
+			crate, _ := stacks[from].Pop()
 			stacks[to].Push(crate)
 		}
 	}
-
-	//	for key, stack := range stacks {
-	//		fmt.Printf("%d: %d %c\n", key, stack.Len(), stack.Peek())
-	//	}
-	for key, stack := range stacks {
-		fmt.Printf("%d: %c\n", key, stack.Peek())
+	if err := scanner.Err(); err != nil {
+		return solver.Answer{}, err
 	}
+
+	return topCrates(stacks)
 }
 
-func PartTwo() {
+func PartTwo(ctx context.Context, r io.Reader) (solver.Answer, error) {
 
-	stacks := map[int]*stack.Stack{}
-	initialState := initialState()
-	for idx, state := range initialState {
-		key := idx + 1
-		stacks[key] = parseStackInitialState(state)
+	scanner := input.NewScanner(r)
+	stacks, err := scanInitialState(scanner)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
-	scanner := bufio.NewScanner(os.Stdin)
-	buffer := stack.New()
-	for {
-		line := scanLine(scanner)
-		move, from, to, success := parseProcedure(line)
+	buffer := collections.NewStack[rune]()
+	for scanner.Scan() {
+		line := scanner.Line()
+		if line.IsBlank() {
+			continue
+		}
 
-		if !success {
-			break
+		move, from, to, err := parseProcedure(line.Text)
+
+		if err != nil {
+			return solver.Answer{}, line.Wrap(err)
+		}
+		tracer.Printf(tracing.Debug, "move %d from %d to %d", move, from, to)
+
+		if err := checkProcedure(stacks, move, from, to); err != nil {
+			return solver.Answer{}, line.Wrap(err)
 		}
 
 		for count := 0; count < move; count++ {
-			crate := stacks[from].Pop()
+			crate, _ := stacks[from].Pop()
 			buffer.Push(crate)
 		}
 		for count := 0; count < move; count++ {
-			crate := buffer.Pop()
+			crate, _ := buffer.Pop()
 			stacks[to].Push(crate)
 		}
 	}
-
-	keys := []int{}
-	for key := range stacks {
-		keys = append(keys, key)
+	if err := scanner.Err(); err != nil {
+		return solver.Answer{}, err
 	}
 
-	sort.Ints(keys)
-	for _, key := range keys {
-		fmt.Printf("%d: %c\n", key, stacks[key].Peek())
-	}
+	return topCrates(stacks)
 }
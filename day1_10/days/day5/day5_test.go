@@ -0,0 +1,42 @@
+package day5
+
+import (
+	"fmt"
+	"testing"
+
+	"Aoc2022/solver"
+	"Aoc2022/solver/solvertest"
+)
+
+func TestPartOne(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, PartOne, input, solver.String("CMZ"))
+}
+
+func TestPartTwo(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, PartTwo, input, solver.String("MCD"))
+}
+
+func TestInvalidProcedure(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.CheckError(t, PartOne, input+"move 1 from 2 to 9\n", 10)
+	solvertest.CheckError(t, PartTwo, input+"move x from 1 to 2\n", 10)
+}
+
+func FuzzParseProcedure(f *testing.F) {
+	for _, seed := range []string{"move 1 from 2 to 1", "move 3 from 1 to 3", "move x from 1 to 2"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, line string) {
+		move, from, to, err := parseProcedure(line)
+		if err != nil {
+			return
+		}
+		formatted := fmt.Sprintf("move %d from %d to %d", move, from, to)
+		move2, from2, to2, err := parseProcedure(formatted)
+		if err != nil || move != move2 || from != from2 || to != to2 {
+			t.Errorf("%q parsed as %q, which does not round-trip", line, formatted)
+		}
+	})
+}
@@ -16,67 +16,101 @@ Q1. 最初のパケット開始マーカーが検出される位置はどこ?
 Q2. メッセージ開始位置のマーカーを検出したい.
 メッセージ開始マーカーは14文字の異なる文字から構成される.
 メッセージ開始マーカーが検出される位置はどこ?
+
+* マーカーの長さは day6.packet, day6.message で変えられる
 */
 
 import (
-	"fmt"
-
-	"github.com/emirpasic/gods/sets/hashset"
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"strings"
+
+	"Aoc2022/params"
+	"Aoc2022/solver"
+	"Aoc2022/tracing"
 )
 
-// terminal からだと 1024 文字以上入力できなかったのでベタ書き
-func input() string {
-	return "dfsfmfbbbjnbbpddfcfjcjbjwjqqbtbntnhtnncfnfpnffwpphwppvbvtvztzszfzhhnqnvnpppmzmczcmzczbznnbssbhhvghhzqzvzttjvtvcccdhccmvccgdgttghthppwlwqlwwcswspsfsdfddhwwzlwzwttlglttsjjthhgcgfcfhfhtftrffwcfcsfccnntmmpvpgpfgfsgfflgfgmffbmmqsmqmmcqqmjjzczwczzpjzzgtgnnqqvdqvqllbttftgtztcztttlslrslrlfldljlwjjnvjjmrrhdrhhflhffgnfnjffmvmvjjspsvvrcchhgngwwdwbwwfhwffmggbsgspsjsfjjdwwbtbdtbdbgdbbbzhzvhhwpwlwfwfswwrgggmggssfwwdrwrccssjttltrrnggdbggvzzzrfzfwzfffnfnmnnzmnznpzzdtzdttbvvgffmnfmfmbbqppcgpgtgpgggcwwlpplslbslblhlmlfmlmldmmjhjjgllglhlssswcssprrqrjrhrvrffpfnpfnppzgztgtdgtdggftgftggqrgrnnqpnpgnpnggsjsvscsnswshwswfwfwqffcrrmprrrcgrrggmnnmzmwzmwzzgzzhnzzthtggtmmdrmrbmmqwwjswwphhzvvjqjrrvzrrmssdvdlvvlhvvjggbwbrwwjqjqmmfllttvpttdvtdtddrttpltpllzrlrwlrrvcvpcphcccqwwtpwwbmmtntfntftvfvfqvvwnvwwvhhlccvrcrlclcqcvqqsvvfjjqmmfhfvvctczzlhlsslpspfsswnsnzsspzpccmssmpmbpbmmvnvsnvsszttrppvgpgnncllrvlrvllcvlvwvhvghgvvnhvvmffclfccvwcvccrwrhhrffqrqcrqqhbqhqssmzsmzsmmzrztzllmclmmbgmbbrmrgrwrgwrrgngvvtqqpbqpppgbgccsjjcmmtdmdjmmjcchvchhbccnjngjjnwjnnrvnrrsqshshszzqnngbnnrnggvwwlzwwlqwqmqdqrdqqrhqhffpgffqgqdggfjgglfglgvgjvjmjtthghrhvhgvhvnncnwcnwntwntnjjqjwwrdwddsggfddnhnsnvsstbbjddfhddhbddwbwbnbrnnrhrqhqdhdllvbbnzzbmbttpjjngnqqcffrsrnsnnhzzgllgvlvcchfhzzmzrmrggdvgvgqqwnqqpdqqvjjvnvhnhhgvhhgllhlzlclbcccwppztzhhvmvzzzsbzbppvdvdtdtdvdsdlsscnnqhnhgngghrrzprzzpddmvvhcvvprplpnlppscctzthtptspttmftmftfjjdfdjfjrfrfbfcchmhnnbddwzwvvpvrvnnslnlnhlnhlltslttqpqvvgzvzsstcstsrtrbtbbzmmzrzqrzqqnmqnnpjpttwgtgzgqqgmgnnzgzrggpbggvssqvssmhmshmssvlvlmvvnhnhddwbbllffgbffbbztthbhdhdghhrccfmfrfmmbdbfdbffzfgfrfqqptpgpjjlvvbjjdzdbbszslzlldnngwgddbmbpbwwhphnhmhhlthhgfhfvvpmvmhvmvdvsddsjtzvfmpsrwrrzgcvnnllfjmvfptwncppfmgqbfzrdpnfddghsqfmnqfwfslrsgjmqtfqwhdddsbhtbtpswcbfppcbhzfzbsqljzndcsrlhrrtstgfhhfsqqrwgnncsmstdmjvfjhqnrczlftzzzhqdzjdcdqcgfpmbqntdhzcvbtpssrvmgjwzwfvtpsrsrwrvrsjgrmzqzvbttscldsnnwzvmlztnnpdjrwvhshpdwgvhmlrnhtfccjnldlnhtfncfjjjztjmhrdqpvhggtqzwjsvwdzhdmwhsmgzjcwzqzlwbrlzsmlwhpjvflnppvrbgrsblmjpnqvgpjbpwbjgjqzwvjbgcplccjgbfwlblzfjqpwszbqbcnlbmfmqpmgspscgfdgfwnmcdzcqnjznndjcvlblszcnpflbjqltpfzhffdbwbshtpnwwlspltpcrvbdtflwbjrfnvrflqpgqtjzqwmmsdvtsmgjtrtbrzchwhpfsznjqcbrjcvwqgrcsqpvfzhrdlmnvvhjzpgpnlrmqfvcnlrlcfjblfcgvngdjfdczsrtnnwjndsfcsdlhdnbtplfnhsmmbldmsjwcblghhgqwbnjvqbqhddrmrtncvwnchsfpddzgrrtzntmwnmdwlrvnjgnzjqvptztnqnqmcjmmrhmtstgdvhffbbmphnbtsdmpmscsfdbnfnchrhhjpsfhhswfszgqfcbdbgnrqhrflpfgfgdcrjvrwbvsfmzzhvzvqzgshcqzlfcljnlgshdlhwdchhhvwlshwdrgjfbnptqqglbpcfgrmqjhqvlbzdwgnzfzlpcpjzqwhbfjljszvjdsrmfzntgnjflhnwhpfrlbpvgzmbqwzlgphmbvbfdqfgqqbhzzvrjftnwjzhlrqccwcfzvntscnbfcsrqqnvlvhszpgwtrzjrqbtslctbhtbczwtmsgwczncbjmzqvnthpwjmsbsjnfpsmghnvtqjjnjfwtnmlthrlcpqhjpnvnbbnwrdjfshwhpdwmsbngfhbhsqphlqspcgzwrgfjmqqtlsfgnvqtdgnhmdvvqzjwlhsnvjczbssrnlhwdmdthmtprjjfttfzbbswfwsvvslfnbcvprzhtcqwdrzjrnjjctqfsjrsddlhzcnstqfppjlqhvcbjbfwndwdtdfvnlwgvdvhzzrqthdhdmddfdwschmpwwrnlgsldzhgjrlmtzrnrrtqfctvbncpcjlsvnwjvhgnbshhwlqhtjghvrctlvngjgjrlgshhwscrdvzjqtfrrbssvqlcjjdljpmlzfqqnqmffpsbvgcqzqdjwczbqwjgfvpdgjglnqdshppcsqcmszhrbcpnhjnczlhwsbnfnzsczjvmftngqcvhgpgwlzbmjnmmdvdjfrcwnjrncvfstrvvqsstphmqdpwjqhzgppmgwlgjhgfwqgmjrlsvqpfqznvbqzgtngvpbpttzvngjwtrjgdnvdggzlnmpgbzhtnfnrhgwvhnpqdwfdvvftzllpqblgdglclwtwbchlvwcmmvtchlntlghztfvgfjczcbqmzgnqmrjcmqvjmjhfpztjcvclblmrctzfmsdfvfpwdcbsglgrsjqcgtcblhbtgcgjlwhhqnwhdnwzlhvphtvmlfnbfmgpnnbzqtdtzqrbhfljlwstlbscnrsvhrbrcthvzcngrttddcjqhtmsfpsgldgtsgjtprsttlssmrrfjmrddqvnqcfmphbnjtdsqvptrdzqbqfjqtnrqtjgdpbrlzrlvwcbcqbcmncfmwcpdhgpjdrdcmrqnflsqbllrqslmhsljwghnwcjwvhchcnlgppmphbqtcdfzjpcqcgsjzvmgfjgfsvmvjfqvtpffbpmhnnnrjmqhhhrhrqfqdwzdvzssslzvqhngdpszztgrvjntcpzhbfmhbpvcndsjbtnwgpmztpbrtjmfqrsvndrspdqmlsbldgghfflncszhnsttfslvwhvfnsmmhbbvjqslsjfqplndndwmbmvgchgvhzclrcnhvgbgmpctrggvqpvqvgvncmdwhpmwhzwhlgsnlnwggfbbvdvqrrsmhwzrrpgrjfshzgzjpfwjhpqmqhbjlwhwsfszlshpzprvgprlprrvlcrmbttjrpqsrdcdfwdrzbcfjpvrlrjjdwhbspqmrblvtldqdhtjtjphpqswgvqfftdgqrtjgsmthlhvlcqrwlqtthwjgrcpwcnsqtssqzpzqptrwjjdfchfmmsrsccnlvqbdmbcdjmhpgvnnlttfhggfphvbwqtcztbnsflztcfpbcpjbcmsplhjdbsmzhgnmfrhscmwmfqbljvhgllvvgqzphzbswdzlhmpcvnntczrcnqvlphhjdjjjnhfzzcjjsdlfccwvswvjfgvmlnpvjvcbpglsgtpj"
-}
+var tracer = tracing.New("day6")
 
-func PartOne() {
+// マーカーの長さ
+var (
+	packetMarker  = params.NewInt("day6.packet", 4, 1, math.MaxInt, "length of the start-of-packet marker (part one)")
+	messageMarker = params.NewInt("day6.message", 14, 1, math.MaxInt, "length of the start-of-message marker (part two)")
+)
 
-	line := input()
-	marker := hashset.New()
+// 入力の 1 行目を信号として読む.
+// 信号は 1 行で長さに上限がないので, 行の長さに上限がある input.Scanner は使わない
+func readSignal(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	line = strings.TrimSpace(line)
+	if len(line) == 0 {
+		return "", errors.New("empty signal")
+	}
+	return line, nil
+}
 
-	result := -1
-	markerLength := 4
-	for idx := 0; idx < len(line)-3; idx++ {
+// 直近 markerLength 文字に出てきた回数を数えながら 1 文字ずつずらし, 全て違う文字になった位置を返す
+func findMarker(ctx context.Context, line string, markerLength int) (int, error) {
+	counts := [256]int{}
+	distinct := 0
 
-		for pos := 0; pos < markerLength; pos++ {
-			marker.Add(line[idx+pos])
-			fmt.Printf("%c", line[idx+pos])
+	for idx := 0; idx < len(line); idx++ {
+		if err := solver.Poll(ctx, idx); err != nil {
+			return 0, err
 		}
 
-		if marker.Size() == markerLength {
-			result = idx + markerLength
-			break
+		if counts[line[idx]] == 0 {
+			distinct++
+		}
+		counts[line[idx]]++
+
+		if markerLength <= idx {
+			leaving := line[idx-markerLength]
+			counts[leaving]--
+			if counts[leaving] == 0 {
+				distinct--
+			}
 		}
 
-		marker.Clear()
-		fmt.Printf("%d \n", marker.Size())
+		if distinct == markerLength {
+			tracer.Printf(tracing.Info, "marker %s ends at %d", line[idx+1-markerLength:idx+1], idx+1)
+			return idx + 1, nil
+		}
 	}
 
-	fmt.Printf("%d", result)
+	return 0, errors.New("marker not found")
 }
 
-func PartTwo() {
-
-	line := input()
-	marker := hashset.New()
+func PartOne(ctx context.Context, r io.Reader) (solver.Answer, error) {
 
-	result := -1
-	markerLength := 14
-	for idx := 0; idx < len(line)-3; idx++ {
+	line, err := readSignal(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
-		for pos := 0; pos < markerLength; pos++ {
-			marker.Add(line[idx+pos])
-			fmt.Printf("%c", line[idx+pos])
-		}
+	result, err := findMarker(ctx, line, packetMarker.Get(ctx))
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(result), nil
+}
 
-		if marker.Size() == markerLength {
-			result = idx + markerLength
-			break
-		}
+func PartTwo(ctx context.Context, r io.Reader) (solver.Answer, error) {
 
-		marker.Clear()
-		fmt.Printf("%d \n", marker.Size())
+	line, err := readSignal(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
-	fmt.Printf("%d", result)
+	result, err := findMarker(ctx, line, messageMarker.Get(ctx))
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(result), nil
 }
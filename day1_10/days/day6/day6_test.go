@@ -0,0 +1,58 @@
+package day6
+
+import (
+	"testing"
+
+	"Aoc2022/params"
+	"Aoc2022/solver"
+	"Aoc2022/solver/solvertest"
+)
+
+var examples = []struct {
+	input   string
+	packet  int
+	message int
+}{
+	{"mjqjpqmgbljsphdztnvjfqwrcgsmlb", 7, 19},
+	{"bvwbjplbgvbhsrlpgdmjqwftvncz", 5, 23},
+	{"nppdvjthqldpwncqszvftbrmjlhg", 6, 23},
+	{"nznrnfrfntjfmvfwmzdfjlvtqnbhcprsg", 10, 29},
+	{"zcfzfwzzqfrljwzlrfnpqdbhtmscgvjw", 11, 26},
+}
+
+func TestPartOne(t *testing.T) {
+	for _, example := range examples {
+		t.Run(example.input, func(t *testing.T) {
+			solvertest.Check(t, PartOne, example.input, solver.Int(example.packet))
+		})
+	}
+}
+
+func TestPartTwo(t *testing.T) {
+	for _, example := range examples {
+		t.Run(example.input, func(t *testing.T) {
+			solvertest.Check(t, PartTwo, example.input, solver.Int(example.message))
+		})
+	}
+}
+
+func TestReference(t *testing.T) {
+	for _, example := range examples {
+		t.Run(example.input, func(t *testing.T) {
+			solvertest.Check(t, ReferencePartOne, example.input, solver.Int(example.packet))
+			solvertest.Check(t, ReferencePartTwo, example.input, solver.Int(example.message))
+		})
+	}
+}
+
+// マーカーの長さを入れ替えると, Part1 と Part2 の答えも入れ替わる
+func TestParams(t *testing.T) {
+	swapped := params.Values{"day6.packet": 14, "day6.message": 4}
+	for _, example := range examples {
+		t.Run(example.input, func(t *testing.T) {
+			solvertest.Check(t, solvertest.WithParams(PartOne, swapped), example.input, solver.Int(example.message))
+			solvertest.Check(t, solvertest.WithParams(PartTwo, swapped), example.input, solver.Int(example.packet))
+			solvertest.Check(t, solvertest.WithParams(ReferencePartOne, swapped), example.input, solver.Int(example.message))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package day6
+
+// 最適化する前の素直な解法. diffcheck で PartOne, PartTwo と答えを比べるために残している
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"Aoc2022/collections"
+	"Aoc2022/solver"
+	"Aoc2022/tracing"
+)
+
+func ReferencePartOne(ctx context.Context, r io.Reader) (solver.Answer, error) {
+
+	line, err := readSignal(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	marker := collections.NewSet[byte]()
+
+	result := -1
+	markerLength := packetMarker.Get(ctx)
+	for idx := 0; idx+markerLength <= len(line); idx++ {
+		if err := solver.Poll(ctx, idx); err != nil {
+			return solver.Answer{}, err
+		}
+
+		for pos := 0; pos < markerLength; pos++ {
+			marker.Add(line[idx+pos])
+		}
+		if tracer.Enabled(tracing.Verbose) {
+			tracer.Printf(tracing.Verbose, "%d: %s has %d distinct", idx, line[idx:idx+markerLength], marker.Len())
+		}
+
+		if marker.Len() == markerLength {
+			result = idx + markerLength
+			break
+		}
+
+		marker.Clear()
+	}
+
+	if result < 0 {
+		return solver.Answer{}, errors.New("marker not found")
+	}
+
+	return solver.Int(result), nil
+}
+
+func ReferencePartTwo(ctx context.Context, r io.Reader) (solver.Answer, error) {
+
+	line, err := readSignal(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	marker := collections.NewSet[byte]()
+
+	result := -1
+	markerLength := messageMarker.Get(ctx)
+	for idx := 0; idx+markerLength <= len(line); idx++ {
+		if err := solver.Poll(ctx, idx); err != nil {
+			return solver.Answer{}, err
+		}
+
+		for pos := 0; pos < markerLength; pos++ {
+			marker.Add(line[idx+pos])
+		}
+		if tracer.Enabled(tracing.Verbose) {
+			tracer.Printf(tracing.Verbose, "%d: %s has %d distinct", idx, line[idx:idx+markerLength], marker.Len())
+		}
+
+		if marker.Len() == markerLength {
+			result = idx + markerLength
+			break
+		}
+
+		marker.Clear()
+	}
+
+	if result < 0 {
+		return solver.Answer{}, errors.New("marker not found")
+	}
+
+	return solver.Int(result), nil
+}
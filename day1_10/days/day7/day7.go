@@ -10,23 +10,49 @@ Q1. デバイスの容量を空けるために 100000 までのディレクト
 そのようなディレクトリの合計サイズはいくつ?
 
 Q2.
-* ファイルシステムで利用可能な合計ディスク容量は 7_000_0000
-* そのうち少なくとも 3_000_0000 の空きが必要
+* ファイルシステムで利用可能な合計ディスク容量は 70_000_000
+* そのうち少なくとも 30_000_000 の空きが必要
 * 更新を実行するのに十分な容量を解放する削除可能なディレクトリを見つける必要があります
 * 十分な容量を空けるのに削除する必要がある最小のディレクトリのサイズ合計は?
+
+* 100000, ディスク容量, 必要な空きは day7.threshold, day7.disk, day7.update で変えられる
 */
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math"
-	"os"
 	"strconv"
 	"strings"
 
-	"github.com/emirpasic/gods/sets/hashset"
+	"Aoc2022/collections"
+	"Aoc2022/input"
+	"Aoc2022/params"
+	"Aoc2022/solver"
+	"Aoc2022/tracing"
 )
 
+var tracer = tracing.New("day7")
+
+var (
+	// Part1 で合計するディレクトリのサイズの上限 (この値は含まない)
+	threshold = params.NewInt("day7.threshold", 100000, 1, math.MaxInt, "directories smaller than this are summed in part one")
+	diskSize  = params.NewInt("day7.disk", 70000000, 1, math.MaxInt, "total disk space (part two)")
+	// 更新に必要な空き容量
+	updateSize = params.NewInt("day7.update", 30000000, 0, math.MaxInt, "free space the update needs (part two)")
+)
+
+func init() {
+	params.AddRule(func(values params.Values) error {
+		if values.Int(diskSize) < values.Int(updateSize) {
+			return fmt.Errorf("%s (%d) must not exceed %s (%d)", updateSize.Key, values.Int(updateSize), diskSize.Key, values.Int(diskSize))
+		}
+		return nil
+	})
+}
+
 type InputType int
 
 const (
@@ -41,45 +67,43 @@ type Directory struct {
 	totalFileSize       int
 	name                string
 	parent              string
-	compoundDirectories hashset.Set
-}
-
-func scanLine(sc *bufio.Scanner) string {
-	sc.Scan()
-	return sc.Text()
+	compoundDirectories collections.Set[string]
 }
 
-func parseInput(line string) (string, string, InputType) {
+func parseInput(line string) (string, string, InputType, error) {
 
 	elements := strings.Split(line, " ")
 
 	if elements[0] == "$" {
 
-		if elements[1] == "cd" {
-			return elements[1], elements[2], Cd
+		if len(elements) == 3 && elements[1] == "cd" {
+			return elements[1], elements[2], Cd, nil
 		}
 
-		if elements[1] == "ls" {
-			return elements[1], "", Ls
+		if len(elements) == 2 && elements[1] == "ls" {
+			return elements[1], "", Ls, nil
 		}
 
-		return "", "", Invalid
+		return "", "", Invalid, errors.New("unknown command " + strconv.Quote(strings.Join(elements[1:], " ")))
 	}
 
 	if len(elements) == 2 {
-		return elements[0], elements[1], State
+		return elements[0], elements[1], State, nil
 	}
 
-	return "", "", Invalid
+	return "", "", Invalid, errors.New("expected a size or dir followed by a name")
 }
 
 func totalSize(name string, directories map[string]*Directory) int {
 
-	size := directories[name].fileSize
-	currentDirectory := directories[name]
+	currentDirectory, exists := directories[name]
+	// ls に出てきたが cd していないディレクトリの中身は分からない
+	if !exists {
+		return 0
+	}
+	size := currentDirectory.fileSize
 
-	for _, directory := range currentDirectory.compoundDirectories.Values() {
-		childName, _ := directory.(string)
+	for childName := range currentDirectory.compoundDirectories {
 		size += totalSize(childName, directories)
 	}
 
@@ -87,36 +111,50 @@ func totalSize(name string, directories map[string]*Directory) int {
 	return size
 }
 
-func PartOne() {
+// 入力のコマンドと出力をたどって, ディレクトリごとのファイルサイズを集計する
+func parseDirectories(r io.Reader) (map[string]*Directory, error) {
 
-	scanner := bufio.NewScanner(os.Stdin)
-	directories := map[string]*Directory{}
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
 
-	currentDirectory := &Directory{fileSize: 0, name: "", parent: ""}
-	for {
+	directories := map[string]*Directory{}
 
-		line := scanLine(scanner)
+	var currentDirectory *Directory
+	for _, line := range lines {
 
-		if len(line) == 0 {
-			break
+		arg1, arg2, inputType, err := parseInput(line.Text)
+		if err != nil {
+			return nil, line.Wrap(err)
 		}
 
-		arg1, arg2, inputType := parseInput(line)
 		if inputType == Cd {
 			dest := arg2
 			if dest == ".." {
+				if currentDirectory == nil || directories[currentDirectory.parent] == nil {
+					return nil, line.Errorf("no parent directory to move to")
+				}
 				currentDirectory = directories[currentDirectory.parent]
 			} else {
 
+				if currentDirectory == nil && dest != "/" {
+					return nil, line.Errorf("first cd must be to the root directory")
+				}
+
 				if dest != "/" {
 					dest = currentDirectory.name + "/" + dest
 				}
 
 				if _, exists := directories[dest]; !exists {
+					parent := ""
+					if currentDirectory != nil {
+						parent = currentDirectory.name
+					}
 					directories[dest] = &Directory{
 						name:                dest,
-						parent:              currentDirectory.name,
-						compoundDirectories: *hashset.New(),
+						parent:              parent,
+						compoundDirectories: collections.NewSet[string](),
 					}
 				}
 
@@ -125,105 +163,71 @@ func PartOne() {
 			continue
 		}
 
+		if currentDirectory == nil {
+			return nil, line.Errorf("no current directory before the first cd")
+		}
+
 		if inputType == State {
 			fileName := arg2
 			if arg1 == "dir" {
 				currentDirectory.compoundDirectories.Add(currentDirectory.name + "/" + fileName)
 			} else {
-				value, _ := strconv.Atoi(arg1)
+				value, err := strconv.Atoi(arg1)
+				if err != nil {
+					return nil, line.Errorf("invalid file size: %w", err)
+				}
 				currentDirectory.fileSize += value
 			}
 			continue
 		}
 
-		if inputType == Ls {
-			continue
-		}
-
-		errMss := line + "invalid command detected"
-		panic(errMss)
+		// Ls は次の行からの出力を読めばよいので何もしない
 	}
 
+	if _, exists := directories["/"]; !exists {
+		return nil, errors.New("root directory not found")
+	}
 	totalSize("/", directories)
 
+	return directories, nil
+}
+
+func PartOne(ctx context.Context, r io.Reader) (solver.Answer, error) {
+
+	directories, err := parseDirectories(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	limit := threshold.Get(ctx)
 	result := 0
 	for _, dir := range directories {
-		if 0 < dir.totalFileSize && dir.totalFileSize < 100000 {
+		if 0 < dir.totalFileSize && dir.totalFileSize < limit {
 			result += dir.totalFileSize
-			//fmt.Printf("%s, %d %d \n", key, dir.fileSize, dir.totalFileSize)
+			tracer.Printf(tracing.Debug, "%s: %d in files, %d in total", dir.name, dir.fileSize, dir.totalFileSize)
 		}
 	}
 
-	fmt.Println(result)
+	return solver.Int(result), nil
 }
 
-func PartTwo() {
-	scanner := bufio.NewScanner(os.Stdin)
-	directories := map[string]*Directory{}
-
-	currentDirectory := &Directory{fileSize: 0, name: "", parent: ""}
-	for {
-
-		line := scanLine(scanner)
-
-		if len(line) == 0 {
-			break
-		}
-
-		arg1, arg2, inputType := parseInput(line)
-		if inputType == Cd {
-			dest := arg2
-			if dest == ".." {
-				currentDirectory = directories[currentDirectory.parent]
-			} else {
-
-				if dest != "/" {
-					dest = currentDirectory.name + "/" + dest
-				}
+func PartTwo(ctx context.Context, r io.Reader) (solver.Answer, error) {
 
-				if _, exists := directories[dest]; !exists {
-					directories[dest] = &Directory{
-						name:                dest,
-						parent:              currentDirectory.name,
-						compoundDirectories: *hashset.New(),
-					}
-				}
-
-				currentDirectory = directories[dest]
-			}
-			continue
-		}
-
-		if inputType == State {
-			fileName := arg2
-			if arg1 == "dir" {
-				currentDirectory.compoundDirectories.Add(currentDirectory.name + "/" + fileName)
-			} else {
-				value, _ := strconv.Atoi(arg1)
-				currentDirectory.fileSize += value
-			}
-			continue
-		}
-
-		if inputType == Ls {
-			continue
-		}
-
-		errMss := line + "invalid command detected"
-		panic(errMss)
+	directories, err := parseDirectories(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
-	totalSize("/", directories)
-
 	// 使用済みファイルサイズをどれだけ減らすべきか
-	requiredSize := directories["/"].totalFileSize - 40000000
+	requiredSize := directories["/"].totalFileSize - (diskSize.Get(ctx) - updateSize.Get(ctx))
+	tracer.Printf(tracing.Info, "%d used, %d to free", directories["/"].totalFileSize, requiredSize)
 	result := math.MaxInt
 	for _, dir := range directories {
 		if requiredSize <= dir.totalFileSize && dir.totalFileSize <= result {
 			result = dir.totalFileSize
-			//fmt.Printf("%s, %d %d \n", key, dir.fileSize, dir.totalFileSize)
+			tracer.Printf(tracing.Debug, "%s: %d in files, %d in total", dir.name, dir.fileSize, dir.totalFileSize)
 		}
 	}
 
-	fmt.Println(result)
+	return solver.Int(result), nil
 }
@@ -0,0 +1,50 @@
+package day7
+
+import (
+	"testing"
+
+	"Aoc2022/params"
+	"Aoc2022/solver"
+	"Aoc2022/solver/solvertest"
+)
+
+func TestPartOne(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, PartOne, input, solver.Int(95437))
+}
+
+func TestPartTwo(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, PartTwo, input, solver.Int(24933642))
+}
+
+func TestInvalidTranscript(t *testing.T) {
+	solvertest.CheckError(t, PartOne, "$ cd /\n$\n", 2)
+	solvertest.CheckError(t, PartOne, "$ cd /\n$ cd ..\n", 2)
+	solvertest.CheckError(t, PartTwo, "$ ls\n1 a\n", 1)
+	solvertest.CheckError(t, PartTwo, "$ cd a\n", 1)
+}
+
+func TestUnvisitedDirectory(t *testing.T) {
+	solvertest.Check(t, PartOne, "$ cd /\n$ ls\ndir a\n100 b\n", solver.Int(100))
+}
+
+func TestParams(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	// 全てのディレクトリを足す
+	solvertest.Check(t, solvertest.WithParams(PartOne, params.Values{"day7.threshold": 100000000}), input, solver.Int(73410244))
+	// 空ける必要がなければ一番小さいディレクトリ
+	solvertest.Check(t, solvertest.WithParams(PartTwo, params.Values{"day7.disk": 48381165, "day7.update": 0}), input, solver.Int(584))
+}
+
+func FuzzParseInput(f *testing.F) {
+	for _, seed := range []string{"$ cd /", "$ ls", "dir a", "14848514 b.txt", "$ cd .."} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, line string) {
+		_, _, inputType, err := parseInput(line)
+		if (err == nil) == (inputType == Invalid) {
+			t.Errorf("parseInput(%q) = %v, %v", line, inputType, err)
+		}
+	})
+}
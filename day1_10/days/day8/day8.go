@@ -33,11 +33,19 @@ Q2.
 */
 
 import (
-	"bufio"
-	"fmt"
-	"os"
+	"context"
+	"errors"
+	"io"
+
+	"Aoc2022/collections"
+	"Aoc2022/grid"
+	"Aoc2022/input"
+	"Aoc2022/solver"
+	"Aoc2022/tracing"
 )
 
+var tracer = tracing.New("day8")
+
 type Visibility struct {
 	top       int
 	right     int
@@ -62,194 +70,171 @@ func (v *Visibility) IsVisible() int {
 	return 1
 }
 
-func calculateVisibility(posX int, posY int, grid [][]Visibility) {
+// 各行の数字を木の高さとして読み, 外周の木に印を付ける
+func parseGrid(r io.Reader) (*grid.Grid[Visibility], error) {
 
-	heightFromLeft := 0
-	for x := 0; x < posX; x++ {
-		if heightFromLeft < grid[posY][x].height {
-			heightFromLeft = grid[posY][x].height
-		}
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
 	}
 
-	heightFromRight := 0
-	width := len(grid[posY])
-	for x := width - 1; posX < x; x-- {
-		if heightFromRight < grid[posY][x].height {
-			heightFromRight = grid[posY][x].height
-		}
-	}
+	rows := make([][]Visibility, 0)
 
-	heightFromTop := 0
-	for y := 0; y < posY; y++ {
-		if heightFromTop < grid[y][posX].height {
-			heightFromTop = grid[y][posX].height
+	for _, row := range lines {
+		line := make([]Visibility, 0)
+		for _, char := range row.Text {
+			if char < '0' || '9' < char {
+				return nil, row.Errorf("invalid tree height %q", char)
+			}
+			height := int(char) - int('0')
+			line = append(line, Visibility{height: height})
 		}
-	}
 
-	heightFromBottom := 0
-	height := len(grid)
-	for y := height - 1; posY < y; y-- {
-		if heightFromBottom < grid[y][posX].height {
-			heightFromBottom = grid[y][posX].height
+		if len(line) == 0 || (len(rows) != 0 && len(line) != len(rows[0])) {
+			return nil, row.Errorf("rows must have the same non-zero width")
 		}
-	}
-
-	grid[posY][posX].left = heightFromLeft
-	grid[posY][posX].right = heightFromRight
-	grid[posY][posX].top = heightFromTop
-	grid[posY][posX].bottom = heightFromBottom
-}
-
-func calculateScore(posX int, posY int, grid [][]Visibility) int {
-
-	if grid[posY][posX].isOutside {
-		return 0
-	}
-
-	width := len(grid[posY])
-	height := len(grid)
 
-	scoreToLeft := 1
-	for x := posX - 1; 0 < x; x-- {
-		if grid[posY][x].height < grid[posY][posX].height {
-			scoreToLeft++
-		} else {
-			break
-		}
+		rows = append(rows, line)
 	}
 
-	scoreToRight := 1
-	for x := posX + 1; x < width-1; x++ {
-		if grid[posY][x].height < grid[posY][posX].height {
-			scoreToRight++
-		} else {
-			break
-		}
+	if len(rows) == 0 {
+		return nil, errors.New("empty grid")
 	}
 
-	scoreToTop := 1
-	for y := posY - 1; 0 < y; y-- {
-		if grid[y][posX].height < grid[posY][posX].height {
-			scoreToTop++
-		} else {
-			break
-		}
+	forest, err := grid.FromRows(rows)
+	if err != nil {
+		return nil, err
 	}
 
-	scoreToBottom := 1
-	for y := posY + 1; y < height-1; y++ {
-		if grid[y][posX].height < grid[posY][posX].height {
-			scoreToBottom++
-		} else {
-			break
+	forest.Each(func(p grid.Point, _ Visibility) bool {
+		for _, direction := range grid.Directions4 {
+			if !forest.In(p.Add(direction)) {
+				forest.Ref(p).isOutside = true
+			}
 		}
-	}
-
-	return scoreToLeft * scoreToRight * scoreToTop * scoreToBottom
-}
+		return true
+	})
 
-func scanLine(sc *bufio.Scanner) string {
-	sc.Scan()
-	return sc.Text()
+	return forest, nil
 }
 
-func PartOne() {
-
-	scanner := bufio.NewScanner(os.Stdin)
-	grid := make([][]Visibility, 0)
-
-	for {
-		input := scanLine(scanner)
-		if len(input) == 0 {
-			break
+// direction に向かってたどる各列について, 盤面のすぐ外側の開始位置を返す
+func edges(forest *grid.Grid[Visibility], direction grid.Point) []grid.Point {
+	result := []grid.Point{}
+	switch direction {
+	case grid.Right:
+		for y := 0; y < forest.Height(); y++ {
+			result = append(result, grid.Point{X: -1, Y: y})
 		}
-
-		line := make([]Visibility, 0)
-		for _, char := range input {
-			if char != '\n' {
-				height := int(char) - int('0')
-				line = append(line, Visibility{height: height})
-			}
+	case grid.Left:
+		for y := 0; y < forest.Height(); y++ {
+			result = append(result, grid.Point{X: forest.Width(), Y: y})
+		}
+	case grid.Down:
+		for x := 0; x < forest.Width(); x++ {
+			result = append(result, grid.Point{X: x, Y: -1})
+		}
+	case grid.Up:
+		for x := 0; x < forest.Width(); x++ {
+			result = append(result, grid.Point{X: x, Y: forest.Height()})
 		}
-
-		grid = append(grid, line)
-	}
-
-	height := len(grid)
-	width := len(grid[0])
-
-	for y := 0; y < height; y++ {
-		grid[y][0].isOutside = true
-		grid[y][width-1].isOutside = true
-	}
-	for x := 0; x < width; x++ {
-		grid[0][x].isOutside = true
-		grid[height-1][x].isOutside = true
 	}
+	return result
+}
 
-	for y := 0; y < height; y++ {
-		for x := 0; x < width; x++ {
-			calculateVisibility(x, y, grid)
+// 4 方向それぞれから各列をたどり, それまでで一番高い木より高い木に印を付ける
+func PartOne(ctx context.Context, r io.Reader) (solver.Answer, error) {
+
+	forest, err := parseGrid(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	visible := grid.New[bool](forest.Width(), forest.Height())
+	for _, direction := range grid.Directions4 {
+		for _, start := range edges(forest, direction) {
+			highest := -1
+			forest.Ray(start, direction, func(p grid.Point, tree Visibility) bool {
+				if highest < tree.height {
+					highest = tree.height
+					visible.Set(p, true)
+				}
+				// 9 より高い木はない
+				return highest < 9
+			})
 		}
 	}
 
 	result := 0
-	for y := 0; y < height; y++ {
-		for x := 0; x < width; x++ {
-			result += grid[y][x].IsVisible()
-			fmt.Printf("%d", grid[y][x].IsVisible())
+	visible.Each(func(_ grid.Point, isVisible bool) bool {
+		if isVisible {
+			result++
 		}
-		fmt.Printf("\n")
+		return true
+	})
+	if tracer.Enabled(tracing.Verbose) {
+		tracer.Lines(tracing.Verbose, visible.Render(func(_ grid.Point, isVisible bool) rune {
+			if isVisible {
+				return '1'
+			}
+			return '0'
+		}))
 	}
 
-	fmt.Println(result)
+	return solver.Int(result), nil
 }
 
-func PartTwo() {
-	scanner := bufio.NewScanner(os.Stdin)
-	grid := make([][]Visibility, 0)
-
-	for {
-		input := scanLine(scanner)
-		if len(input) == 0 {
-			break
-		}
+type tree struct {
+	distance int
+	height   int
+}
 
-		line := make([]Visibility, 0)
-		for _, char := range input {
-			if char != '\n' {
-				height := int(char) - int('0')
-				line = append(line, Visibility{height: height})
-			}
+// 4 方向それぞれから各列をたどり, 通ってきた方向に見える木の数を掛けていく.
+// 自分より低い木は後ろの木の視界を遮らないので, stack には高さが減っていく順に木が残る
+func PartTwo(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	forest, err := parseGrid(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	scores := grid.New[int](forest.Width(), forest.Height())
+	scores.Fill(1)
+
+	for _, direction := range grid.Directions4 {
+		for _, start := range edges(forest, direction) {
+			behind := collections.NewStack[tree]()
+			distance := 0
+			forest.Ray(start, direction, func(p grid.Point, visibility Visibility) bool {
+				for {
+					top, ok := behind.Peek()
+					if !ok || visibility.height <= top.height {
+						break
+					}
+					behind.Pop()
+				}
+
+				// 遮る木がなければ端まで見える
+				blocker := tree{}
+				if top, ok := behind.Peek(); ok {
+					blocker = top
+				}
+				*scores.Ref(p) *= distance - blocker.distance
+
+				behind.Push(tree{distance: distance, height: visibility.height})
+				distance++
+				return true
+			})
 		}
-
-		grid = append(grid, line)
-	}
-
-	height := len(grid)
-	width := len(grid[0])
-
-	for y := 0; y < height; y++ {
-		grid[y][0].isOutside = true
-		grid[y][width-1].isOutside = true
-	}
-	for x := 0; x < width; x++ {
-		grid[0][x].isOutside = true
-		grid[height-1][x].isOutside = true
 	}
 
 	result := 0
-	for y := 0; y < height; y++ {
-		for x := 0; x < width; x++ {
-			score := calculateScore(x, y, grid)
-			//fmt.Printf("%d ", score)
-			if result < score {
-				result = score
-				//fmt.Printf("updated at: (%d, %d), %d \n", x, y, result)
-			}
+	scores.Each(func(p grid.Point, score int) bool {
+		if result < score {
+			result = score
+			tracer.Printf(tracing.Debug, "updated at: (%d, %d), %d", p.X, p.Y, result)
 		}
-		//fmt.Printf("\n")
-	}
+		return true
+	})
 
-	fmt.Println(result)
+	return solver.Int(result), nil
 }
@@ -0,0 +1,24 @@
+package day8
+
+import (
+	"testing"
+
+	"Aoc2022/solver"
+	"Aoc2022/solver/solvertest"
+)
+
+func TestPartOne(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, PartOne, input, solver.Int(21))
+}
+
+func TestPartTwo(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, PartTwo, input, solver.Int(8))
+}
+
+func TestReference(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, ReferencePartOne, input, solver.Int(21))
+	solvertest.Check(t, ReferencePartTwo, input, solver.Int(8))
+}
@@ -0,0 +1,122 @@
+package day8
+
+// 最適化する前の素直な解法. diffcheck で PartOne, PartTwo と答えを比べるために残している
+
+import (
+	"context"
+	"io"
+
+	"Aoc2022/grid"
+	"Aoc2022/solver"
+	"Aoc2022/tracing"
+)
+
+// from から direction に向かって見える木のうち一番高いもの
+func highestTree(from grid.Point, direction grid.Point, forest *grid.Grid[Visibility]) int {
+	highest := 0
+	forest.Ray(from, direction, func(_ grid.Point, tree Visibility) bool {
+		if highest < tree.height {
+			highest = tree.height
+		}
+		return true
+	})
+	return highest
+}
+
+func calculateVisibility(pos grid.Point, forest *grid.Grid[Visibility]) {
+
+	tree := forest.Ref(pos)
+	tree.left = highestTree(pos, grid.Left, forest)
+	tree.right = highestTree(pos, grid.Right, forest)
+	tree.top = highestTree(pos, grid.Up, forest)
+	tree.bottom = highestTree(pos, grid.Down, forest)
+}
+
+// from から direction に向かって, 端か同じ高さ以上の木までに見える木の数
+func viewingDistance(from grid.Point, direction grid.Point, forest *grid.Grid[Visibility]) int {
+	height := forest.At(from).height
+	distance := 0
+	forest.Ray(from, direction, func(_ grid.Point, tree Visibility) bool {
+		distance++
+		return tree.height < height
+	})
+	return distance
+}
+
+func calculateScore(pos grid.Point, forest *grid.Grid[Visibility]) int {
+
+	if forest.At(pos).isOutside {
+		return 0
+	}
+
+	score := 1
+	for _, direction := range grid.Directions4 {
+		score *= viewingDistance(pos, direction, forest)
+	}
+	return score
+}
+
+func ReferencePartOne(ctx context.Context, r io.Reader) (solver.Answer, error) {
+
+	forest, err := parseGrid(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	// 1 マスごとに行と列を全て見るので, 大きな盤面では時間がかかる
+	cells := 0
+	forest.Each(func(p grid.Point, _ Visibility) bool {
+		if err = solver.Poll(ctx, cells); err != nil {
+			return false
+		}
+		cells++
+		calculateVisibility(p, forest)
+		return true
+	})
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	result := 0
+	forest.Each(func(p grid.Point, tree Visibility) bool {
+		result += tree.IsVisible()
+		return true
+	})
+	if tracer.Enabled(tracing.Verbose) {
+		tracer.Lines(tracing.Verbose, forest.Render(func(_ grid.Point, tree Visibility) rune {
+			return rune('0' + tree.IsVisible())
+		}))
+	}
+
+	return solver.Int(result), nil
+}
+
+func ReferencePartTwo(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	forest, err := parseGrid(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	result := 0
+	cells := 0
+	forest.Each(func(p grid.Point, _ Visibility) bool {
+		if err = solver.Poll(ctx, cells); err != nil {
+			return false
+		}
+		cells++
+		score := calculateScore(p, forest)
+		if tracer.Enabled(tracing.Verbose) {
+			tracer.Printf(tracing.Verbose, "(%d, %d): %d", p.X, p.Y, score)
+		}
+		if result < score {
+			result = score
+			tracer.Printf(tracing.Debug, "updated at: (%d, %d), %d", p.X, p.Y, result)
+		}
+		return true
+	})
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	return solver.Int(result), nil
+}
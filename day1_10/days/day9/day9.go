@@ -22,19 +22,30 @@ Q2.
 * ロープを10マスに拡張する
 * 各部分は Q1 の T と同じ動きをする
 * ロープの尾が1回以上訪れたマスはいくつ?
+
+* Q2 のロープの長さは day9.rope で変えられる
 */
 
 import (
-	"bufio"
-	"fmt"
-	"math"
-	"os"
+	"context"
+	"errors"
+	"io"
 	"strconv"
 	"strings"
 
-	"github.com/emirpasic/gods/sets/hashset"
+	"Aoc2022/collections"
+	"Aoc2022/grid"
+	"Aoc2022/input"
+	"Aoc2022/params"
+	"Aoc2022/solver"
+	"Aoc2022/tracing"
 )
 
+var tracer = tracing.New("day9")
+
+// Part2 のロープの長さ (頭を含む)
+var ropeLength = params.NewInt("day9.rope", 10, 2, 1000, "number of knots in the part two rope, head included")
+
 type Direction int
 
 const (
@@ -49,14 +60,13 @@ type Command struct {
 	value     int
 }
 
-type Point struct {
-	x int
-	y int
-}
-
-func parseCommand(line string) Command {
+func parseCommand(line string) (Command, error) {
 
 	input := strings.Split(line, " ")
+	if len(input) != 2 {
+		return Command{}, errors.New("expected a direction and a step count")
+	}
+
 	var direction Direction
 
 	switch input[0] {
@@ -68,248 +78,232 @@ func parseCommand(line string) Command {
 		direction = Right
 	case "L":
 		direction = Left
+	default:
+		return Command{}, errors.New("unknown direction " + input[0])
 	}
 
-	value, _ := strconv.Atoi(input[1])
-	return Command{direction: direction, value: value}
-}
-
-func scanLine(sc *bufio.Scanner) string {
-	sc.Scan()
-	return sc.Text()
+	value, err := strconv.Atoi(input[1])
+	if err != nil {
+		return Command{}, err
+	}
+	if value < 0 {
+		return Command{}, errors.New("negative step count " + input[1])
+	}
+	return Command{direction: direction, value: value}, nil
 }
 
-func printPosition(hX int, hY int, tX int, tY int, visitedTable hashset.Set) {
+func parseCommands(r io.Reader) ([]Command, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
 
-	width := 6
-	height := 5
+	commands := make([]Command, 0, len(lines))
+	for _, line := range lines {
+		command, err := parseCommand(line.Text)
+		if err != nil {
+			return nil, line.Wrap(err)
+		}
+		commands = append(commands, command)
+	}
+	return commands, nil
+}
 
-	for y := height - 1; 0 <= y; y-- {
-		for x := 0; x < width; x++ {
+// この日の座標は Y が上に向かって増えるので, 上の行から描くために反転させる
+func render(width int, height int, glyph func(p grid.Point) rune) {
+	rows := grid.Render(grid.Size(width, height), func(p grid.Point) rune {
+		return glyph(grid.Point{X: p.X, Y: height - 1 - p.Y})
+	})
+	tracer.Lines(tracing.Verbose, append(rows, ""))
+}
 
-			if visitedTable.Contains(convertToString(x, y)) {
-				fmt.Print("#")
-				continue
-			}
+func traceCommand(command Command) {
+	tracer.Printf(tracing.Debug, "%c %d", "UDRL"[command.direction], command.value)
+}
 
-			if x == hX && y == hY {
-				fmt.Print("H")
-			} else if x == tX && y == tY {
-				fmt.Print("T")
-			} else {
-				fmt.Printf(".")
-			}
+func printPosition(head grid.Point, tail grid.Point, visitedTable collections.Set[grid.Point]) {
 
+	render(6, 5, func(p grid.Point) rune {
+		if visitedTable.Contains(p) {
+			return '#'
 		}
 
-		fmt.Printf("\n")
-	}
+		if p == head {
+			return 'H'
+		} else if p == tail {
+			return 'T'
+		}
+		return '.'
+	})
 }
 
-func printAttitude(body []Point, visitedTable hashset.Set) {
-
-	width := 10
-	height := 10
-
-	for y := height - 1; 0 <= y; y-- {
-		for x := 0; x < width; x++ {
-
-			containsValue := -1
-			for idx := 0; idx < len(body); idx++ {
-				if x == body[idx].x && y == body[idx].y {
-					containsValue = idx
-					fmt.Printf("%d", idx)
-					break
-				}
-			}
+func printAttitude(body []grid.Point, visitedTable collections.Set[grid.Point]) {
 
-			if containsValue == -1 {
-				fmt.Printf(".")
+	render(10, 10, func(p grid.Point) rune {
+		for idx := 0; idx < len(body); idx++ {
+			if p == body[idx] {
+				return rune('0' + idx)
 			}
 		}
+		return '.'
+	})
+}
 
-		fmt.Printf("\n")
+// 長さ 2 のロープは頭と尾と尾の跡を, それより長いロープは各節の番号を書く
+func traceRope(rope []grid.Point, visitedTable collections.Set[grid.Point]) {
+	if len(rope) == 2 {
+		printPosition(rope[0], rope[1], visitedTable)
+		return
 	}
+	printAttitude(rope, visitedTable)
 }
 
-func convertToString(x int, y int) string {
-	str := strconv.Itoa(x)
-	str += " , "
-	str += strconv.Itoa(y)
-	return str
+// Y が上に向かって増える向きでの 1 歩
+func (d Direction) delta() grid.Point {
+	switch d {
+	case Up:
+		return grid.Down
+	case Down:
+		return grid.Up
+	case Right:
+		return grid.Right
+	default:
+		return grid.Left
+	}
 }
 
-func convertToStringFromPoint(p *Point) string {
-	return convertToString(p.x, p.y)
+func isAdjacent(head grid.Point, tail grid.Point) bool {
+	return head.Chebyshev(tail) <= 1
 }
 
-func isAdjacent(x1 int, y1 int, x2 int, y2 int) bool {
-	diffX := math.Abs(float64(x2 - x1))
-	diffY := math.Abs(float64(y2 - y1))
-
-	if 3 <= diffX+diffY {
-		return false
+// 離れていれば, 尾を頭の方に縦横斜めのいずれかに 1 歩寄せる
+func follow(head grid.Point, tail grid.Point) grid.Point {
+	if isAdjacent(head, tail) {
+		return tail
 	}
+	return tail.Add(head.Sub(tail).Sign())
+}
 
-	if (1 < diffX) || (1 < diffY) {
-		return false
-	}
+// これより広い範囲は盤面にせず Set に記録する
+const maxDenseCells = 1 << 24
 
-	return true
+// 頭が通る範囲. 他の節は前の節との間にしか動かないので, ロープ全体もこの範囲に収まる.
+// 盤面にできないほど広ければ fits は false. 座標の計算があふれる前にそこで打ち切る
+func headBounds(commands []Command) (bounds grid.Rect, fits bool) {
+	head := grid.Point{}
+	bounds = grid.Rect{}.Extend(head)
+	for _, command := range commands {
+		if maxDenseCells < command.value {
+			return grid.Rect{}, false
+		}
+		head = head.Add(grid.Point{
+			X: command.direction.delta().X * command.value,
+			Y: command.direction.delta().Y * command.value,
+		})
+		bounds = bounds.Extend(head)
+		if maxDenseCells < bounds.Width() || maxDenseCells < bounds.Height() {
+			return grid.Rect{}, false
+		}
+	}
+	return bounds, true
 }
 
-func getTailMovedPosition(x int, diff int) int {
-	if 0 < diff {
-		x += 1
-	} else if diff < 0 {
-		x -= 1
+// 訪れたマスを記録し, 初めて訪れたマスなら true を返す関数を作る
+func visitor(bounds grid.Rect, fits bool) func(p grid.Point) bool {
+	if !fits || bounds.Empty() || maxDenseCells/bounds.Height() < bounds.Width() {
+		visited := collections.NewSet[grid.Point]()
+		return func(p grid.Point) bool {
+			if visited.Contains(p) {
+				return false
+			}
+			visited.Add(p)
+			return true
+		}
 	}
 
-	return x
+	// 範囲の左上が (0, 0) になるようにずらして盤面に記録する
+	visited := grid.New[bool](bounds.Width(), bounds.Height())
+	return func(p grid.Point) bool {
+		cell := visited.Ref(p.Sub(bounds.Min))
+		if *cell {
+			return false
+		}
+		*cell = true
+		return true
+	}
 }
 
-func PartOne() {
+// 長さ length のロープを動かし, 尾が訪れたマスの数を返す
+func simulate(ctx context.Context, commands []Command, length int) (int, error) {
+	visit := visitor(headBounds(commands))
 
-	scanner := bufio.NewScanner(os.Stdin)
-	commands := make([]Command, 0)
+	rope := make([]grid.Point, length)
+	visit(rope[0])
+	result := 1
+	steps := 0
 
-	for {
-		line := scanLine(scanner)
-
-		if len(line) == 0 {
-			break
-		}
-
-		command := parseCommand(line)
-		commands = append(commands, command)
+	// 盤面を書くときだけ, 参照解法と同じ絵になるよう尾の跡を Set にも残す
+	var trail collections.Set[grid.Point]
+	if tracer.Enabled(tracing.Verbose) {
+		trail = collections.NewSet(rope[length-1])
 	}
 
-	hX := 0
-	hY := 0
-	tX := 0
-	tY := 0
-
-	visitedPoints := hashset.New()
-	visitedPoints.Add(convertToString(tX, tY))
-
 	for _, command := range commands {
+		traceCommand(command)
+		delta := command.direction.delta()
 		for count := 0; count < command.value; count++ {
-
-			//fmt.Printf("%d, %d \n", command.direction, command.value)
-
-			switch command.direction {
-			case Up:
-				hY++
-			case Down:
-				hY--
-			case Right:
-				hX++
-			case Left:
-				hX--
+			if err := solver.Poll(ctx, steps); err != nil {
+				return 0, err
 			}
+			steps++
 
-			if !isAdjacent(hX, hY, tX, tY) {
-
-				diffX := hX - tX
-				diffY := hY - tY
-
-				if 1 < diffY {
-					tY += 1
-					tX += diffX
-				} else if diffY < -1 {
-					tY -= 1
-					tX += diffX
-				} else if 1 < diffX {
-					tX += 1
-					tY += diffY
-				} else if diffX < -1 {
-					tX -= 1
-					tY += diffY
-				}
+			rope[0] = rope[0].Add(delta)
 
+			for idx := 1; idx < length; idx++ {
+				moved := follow(rope[idx-1], rope[idx])
+				// 動かなかった節より後ろも動かない
+				if moved == rope[idx] {
+					break
+				}
+				rope[idx] = moved
 			}
 
-			visitedPoints.Add(convertToString(tX, tY))
-			//printPosition(hX, hY, tX, tY, *visitedPoints)
+			if visit(rope[length-1]) {
+				result++
+			}
+			if trail != nil {
+				trail.Add(rope[length-1])
+				traceRope(rope, trail)
+			}
 		}
-		//fmt.Printf("\n")
 	}
 
-	fmt.Println(visitedPoints.Size())
+	return result, nil
 }
 
-func PartTwo() {
-	scanner := bufio.NewScanner(os.Stdin)
-	commands := make([]Command, 0)
-
-	for {
-		line := scanLine(scanner)
+func PartOne(ctx context.Context, r io.Reader) (solver.Answer, error) {
 
-		if len(line) == 0 {
-			break
-		}
-
-		command := parseCommand(line)
-		commands = append(commands, command)
+	commands, err := parseCommands(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
-	const bodyLength = 10
-	body := [bodyLength]Point{}
-
-	visitedPoints := hashset.New()
-	visitedPoints.Add(convertToStringFromPoint(&body[bodyLength-1]))
-
-	for _, command := range commands {
-		for count := 0; count < command.value; count++ {
-
-			switch command.direction {
-			case Up:
-				body[0].y++
-			case Down:
-				body[0].y--
-			case Right:
-				body[0].x++
-			case Left:
-				body[0].x--
-			}
-
-			//fmt.Printf("%d, %d \n", command.direction, command.value)
-			for idx := 1; idx < bodyLength; idx++ {
-
-				hX := body[idx-1].x
-				hY := body[idx-1].y
-				tX := body[idx].x
-				tY := body[idx].y
-
-				if !isAdjacent(hX, hY, tX, tY) {
-
-					diffX := hX - tX
-					diffY := hY - tY
-
-					if 1 < diffY {
-						tY += 1
-						tX = getTailMovedPosition(tX, diffX)
-					} else if diffY < -1 {
-						tY -= 1
-						tX = getTailMovedPosition(tX, diffX)
-					} else if 1 < diffX {
-						tX += 1
-						tY = getTailMovedPosition(tY, diffY)
-					} else if diffX < -1 {
-						tX -= 1
-						tY = getTailMovedPosition(tY, diffY)
-					}
-				}
-
-				body[idx].x = tX
-				body[idx].y = tY
-			}
+	result, err := simulate(ctx, commands, 2)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(result), nil
+}
 
-			visitedPoints.Add(convertToStringFromPoint(&body[bodyLength-1]))
-			//printAttitude(body[:], *visitedPoints)
-		}
-		//fmt.Printf("\n")
+func PartTwo(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	commands, err := parseCommands(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
-	fmt.Println(visitedPoints.Size())
+	result, err := simulate(ctx, commands, ropeLength.Get(ctx))
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(result), nil
 }
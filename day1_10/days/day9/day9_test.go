@@ -0,0 +1,82 @@
+package day9
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"Aoc2022/params"
+	"Aoc2022/solver"
+	"Aoc2022/solver/solvertest"
+)
+
+func TestPartOne(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, PartOne, input, solver.Int(13))
+}
+
+func TestPartTwo(t *testing.T) {
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"testdata/example.txt", 1},
+		{"testdata/larger_example.txt", 36},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			input := solvertest.ReadFile(t, test.path)
+			solvertest.Check(t, PartTwo, input, solver.Int(test.want))
+		})
+	}
+}
+
+func TestReference(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	solvertest.Check(t, ReferencePartOne, input, solver.Int(13))
+
+	larger := solvertest.ReadFile(t, "testdata/larger_example.txt")
+	solvertest.Check(t, ReferencePartTwo, larger, solver.Int(36))
+}
+
+func TestInvalidCommand(t *testing.T) {
+	solvertest.CheckError(t, PartOne, "R 4\nX 1\n", 2)
+	solvertest.CheckError(t, PartTwo, "R 4\nU 4\nL three\n", 3)
+	solvertest.CheckError(t, PartOne, "R 4\nU -1\n", 2)
+}
+
+// 頭の座標が int からあふれる入力でも panic せず, 打ち切られるまで動かし続ける
+func TestHugeStepCount(t *testing.T) {
+	input := "R 9223372036854775807\nR 1\n"
+	for name, f := range map[string]solver.Func{"PartOne": PartOne, "PartTwo": PartTwo, "ReferencePartOne": ReferencePartOne} {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err := f(ctx, strings.NewReader(input))
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%s: got %v, want context.DeadlineExceeded", name, err)
+		}
+	}
+}
+
+// 長さ 2 のロープは Part1 と同じ
+func TestParams(t *testing.T) {
+	input := solvertest.ReadFile(t, "testdata/example.txt")
+	short := params.Values{"day9.rope": 2}
+	solvertest.Check(t, solvertest.WithParams(PartTwo, short), input, solver.Int(13))
+	solvertest.Check(t, solvertest.WithParams(ReferencePartTwo, short), input, solver.Int(13))
+}
+
+func FuzzParseCommand(f *testing.F) {
+	for _, seed := range []string{"R 4", "U 4", "L 3", "D 1", "X 1"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, line string) {
+		command, err := parseCommand(line)
+		if err == nil && command.value < 0 {
+			t.Errorf("parseCommand(%q) returned a negative step count", line)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package day9
+
+// 最適化する前の素直な解法. diffcheck で PartOne, PartTwo と答えを比べるために残している
+
+import (
+	"context"
+	"io"
+
+	"Aoc2022/collections"
+	"Aoc2022/grid"
+	"Aoc2022/solver"
+	"Aoc2022/tracing"
+)
+
+func ReferencePartOne(ctx context.Context, r io.Reader) (solver.Answer, error) {
+
+	commands, err := parseCommands(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	head := grid.Point{}
+	tail := grid.Point{}
+
+	visitedPoints := collections.NewSet(tail)
+
+	steps := 0
+	for _, command := range commands {
+		traceCommand(command)
+		for count := 0; count < command.value; count++ {
+			if err := solver.Poll(ctx, steps); err != nil {
+				return solver.Answer{}, err
+			}
+			steps++
+
+			head = head.Add(command.direction.delta())
+			tail = follow(head, tail)
+
+			visitedPoints.Add(tail)
+			if tracer.Enabled(tracing.Verbose) {
+				printPosition(head, tail, visitedPoints)
+			}
+		}
+	}
+
+	return solver.Int(visitedPoints.Len()), nil
+}
+
+func ReferencePartTwo(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	commands, err := parseCommands(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	bodyLength := ropeLength.Get(ctx)
+	body := make([]grid.Point, bodyLength)
+
+	visitedPoints := collections.NewSet(body[bodyLength-1])
+
+	steps := 0
+	for _, command := range commands {
+		traceCommand(command)
+		for count := 0; count < command.value; count++ {
+			if err := solver.Poll(ctx, steps); err != nil {
+				return solver.Answer{}, err
+			}
+			steps++
+
+			body[0] = body[0].Add(command.direction.delta())
+
+			for idx := 1; idx < bodyLength; idx++ {
+				body[idx] = follow(body[idx-1], body[idx])
+			}
+
+			visitedPoints.Add(body[bodyLength-1])
+			if tracer.Enabled(tracing.Verbose) {
+				printAttitude(body, visitedPoints)
+			}
+		}
+	}
+
+	return solver.Int(visitedPoints.Len()), nil
+}
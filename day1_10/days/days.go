@@ -0,0 +1,132 @@
+package days
+
+/*
+days
+
+各日の解法の一覧.
+日とパートの番号から解法を引けるようにしておく.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"Aoc2022/days/day1"
+	"Aoc2022/days/day10"
+	"Aoc2022/days/day2"
+	"Aoc2022/days/day3"
+	"Aoc2022/days/day4"
+	"Aoc2022/days/day5"
+	"Aoc2022/days/day6"
+	"Aoc2022/days/day7"
+	"Aoc2022/days/day8"
+	"Aoc2022/days/day9"
+	"Aoc2022/solver"
+)
+
+var (
+	ErrUnknownDay  = errors.New("unknown day")
+	ErrUnknownPart = errors.New("unknown part")
+)
+
+// Entry は登録済みの解法 1 つ分
+type Entry struct {
+	Day    int
+	Part   int
+	Title  string
+	Solver solver.Solver
+	// 最適化する前の素直な解法. なければ nil
+	Reference solver.Solver
+}
+
+type registration struct {
+	title string
+	// Part1, Part2 の順
+	parts []solver.Func
+}
+
+var table = map[int]registration{
+	1:  {"Calorie Counting", []solver.Func{day1.PartOne, day1.PartTwo}},
+	2:  {"Rock Paper Scissors", []solver.Func{day2.PartOne, day2.PartTwo}},
+	3:  {"Rucksack Reorganization", []solver.Func{day3.PartOne, day3.PartTwo}},
+	4:  {"Camp Cleanup", []solver.Func{day4.PartOne, day4.PartTwo}},
+	5:  {"Supply Stacks", []solver.Func{day5.PartOne, day5.PartTwo}},
+	6:  {"Tuning Trouble", []solver.Func{day6.PartOne, day6.PartTwo}},
+	7:  {"No Space Left On Device", []solver.Func{day7.PartOne, day7.PartTwo}},
+	8:  {"Treetop Tree House", []solver.Func{day8.PartOne, day8.PartTwo}},
+	9:  {"Rope Bridge", []solver.Func{day9.PartOne, day9.PartTwo}},
+	10: {"Cathode-Ray Tube", []solver.Func{day10.PartOne, day10.PartTwo}},
+}
+
+// 最適化した日の, 最適化前の解法. Part1, Part2 の順
+var references = map[int][]solver.Func{
+	4: {day4.ReferencePartOne, day4.ReferencePartTwo},
+	6: {day6.ReferencePartOne, day6.ReferencePartTwo},
+	8: {day8.ReferencePartOne, day8.ReferencePartTwo},
+	9: {day9.ReferencePartOne, day9.ReferencePartTwo},
+}
+
+// Days は登録済みの日を昇順で返す
+func Days() []int {
+	result := make([]int, 0, len(table))
+	for day := range table {
+		result = append(result, day)
+	}
+	sort.Ints(result)
+	return result
+}
+
+// Lookup は day 日目の part の解法を返す
+func Lookup(day int, part int) (Entry, error) {
+	registered, exists := table[day]
+	if !exists {
+		days := Days()
+		return Entry{}, fmt.Errorf("%w %d (available: %d-%d)", ErrUnknownDay, day, days[0], days[len(days)-1])
+	}
+
+	parts := registered.parts
+	if part < 1 || len(parts) < part {
+		return Entry{}, fmt.Errorf("%w %d for day %d (available: 1-%d)", ErrUnknownPart, part, day, len(parts))
+	}
+
+	entry := Entry{Day: day, Part: part, Title: registered.title, Solver: parts[part-1]}
+	if reference, exists := references[day]; exists {
+		entry.Reference = reference[part-1]
+	}
+	return entry, nil
+}
+
+// Parts は day 日目の全パートを返す
+func Parts(day int) ([]Entry, error) {
+	if _, err := Lookup(day, 1); err != nil {
+		return nil, err
+	}
+
+	result := make([]Entry, 0, len(table[day].parts))
+	for idx := range table[day].parts {
+		entry, _ := Lookup(day, idx+1)
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// ReferenceDays は最適化前の解法が残っている日を昇順で返す
+func ReferenceDays() []int {
+	result := make([]int, 0, len(references))
+	for day := range references {
+		result = append(result, day)
+	}
+	sort.Ints(result)
+	return result
+}
+
+// All は登録済みの全ての解法を日, パートの順で返す
+func All() []Entry {
+	result := []Entry{}
+	for _, day := range Days() {
+		entries, _ := Parts(day)
+		result = append(result, entries...)
+	}
+	return result
+}
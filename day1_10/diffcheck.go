@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"Aoc2022/diffcheck"
+	"Aoc2022/params"
+)
+
+func diffcheckCommand(args []string) error {
+	fs := flag.NewFlagSet("diffcheck", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to check")
+	n := fs.Int("n", 1000, "number of generated inputs to try")
+	seed := fs.Int64("seed", 1, "seed of the first generated input")
+	size := fs.Int("size", 20, "maximum input size passed to the generator")
+	output := fs.String("o", "", "save the minimized input to this `file`")
+	timeout := fs.Duration("timeout", 10*time.Second, "time limit per part; inputs where either solver times out are skipped")
+	configPath, overrides := paramFlags(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	if *day == 0 {
+		return errors.New("-day is required")
+	}
+	values, err := loadParams(*configPath, overrides)
+	if err != nil {
+		return err
+	}
+
+	mismatch, err := diffcheck.Run(params.With(context.Background(), values), *day, diffcheck.Options{N: *n, Seed: *seed, MaxSize: *size, Timeout: *timeout})
+	if err != nil {
+		return err
+	}
+	if mismatch == nil {
+		fmt.Printf("day%d: reference and optimized solvers agree on %d input(s)\n", *day, *n)
+		return nil
+	}
+
+	if err := diffcheck.Write(os.Stdout, mismatch); err != nil {
+		return err
+	}
+	if *output != "" {
+		if err := os.WriteFile(*output, mismatch.Input, 0o644); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("day%d part%d disagrees", mismatch.Day, mismatch.Part)
+}
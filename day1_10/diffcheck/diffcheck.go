@@ -0,0 +1,156 @@
+package diffcheck
+
+/*
+diffcheck
+
+最適化した解法と最適化する前の解法に gen で作った同じ入力を与え, 答えが食い違う入力を探す.
+見つかった入力は食い違ったまま小さくしてから報告する.
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+
+	"Aoc2022/days"
+	"Aoc2022/gen"
+	"Aoc2022/runner"
+)
+
+var ErrNoReference = errors.New("no reference solver")
+
+type Options struct {
+	// 試す入力の数
+	N int
+	// i 番目の入力は Seed+i から作る
+	Seed int64
+	// 入力の大きさの上限. 0 以下なら gen の DefaultSize
+	MaxSize int
+	// 0 より大きければ, 1 パートを解く時間の上限
+	Timeout time.Duration
+}
+
+// Mismatch は 2 つの解法の答えが食い違った入力
+type Mismatch struct {
+	Day  int
+	Part int
+	Seed int64
+	Size int
+	// gen が作った入力と, それを小さくした入力
+	Original []byte
+	Input    []byte
+	// Input に対するそれぞれの結果
+	Reference runner.Result
+	Optimized runner.Result
+}
+
+// Run は day 日目の全パートについて, 最初に答えが食い違った入力を返す.
+// 全て一致すれば nil を返す
+func Run(ctx context.Context, day int, options Options) (*Mismatch, error) {
+	entries, err := days.Parts(day)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.Reference == nil {
+			return nil, fmt.Errorf("%w for day %d (available: %v)", ErrNoReference, day, days.ReferenceDays())
+		}
+	}
+
+	generator, err := gen.Lookup(day)
+	if err != nil {
+		return nil, err
+	}
+	maxSize := options.MaxSize
+	if maxSize <= 0 {
+		maxSize = generator.DefaultSize
+	}
+
+	sizes := rand.New(rand.NewSource(options.Seed))
+	for idx := 0; idx < options.N; idx++ {
+		seed := options.Seed + int64(idx)
+		size := 1 + sizes.Intn(maxSize)
+		data, err := gen.Bytes(day, seed, size)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if _, _, same := Check(ctx, entry, data, options.Timeout); same {
+				continue
+			}
+
+			input := Minimize(data, func(candidate []byte) bool {
+				_, _, same := Check(ctx, entry, candidate, options.Timeout)
+				return !same
+			})
+			reference, optimized, _ := Check(ctx, entry, input, options.Timeout)
+			return &Mismatch{
+				Day:       day,
+				Part:      entry.Part,
+				Seed:      seed,
+				Size:      size,
+				Original:  data,
+				Input:     input,
+				Reference: reference,
+				Optimized: optimized,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Check は entry の 2 つの解法で input を解き, 結果が一致するか確かめる.
+// どちらもエラーなら一致とみなす. panic はいつも不一致とする.
+// どちらかがタイムアウトしたら比べようがないので一致とみなす
+func Check(ctx context.Context, entry days.Entry, input []byte, timeout time.Duration) (reference runner.Result, optimized runner.Result, same bool) {
+	// Data が nil だとファイルから読もうとするので, 空でも nil にしない
+	data := append([]byte{}, input...)
+
+	optimized = runner.Run(ctx, runner.Job{Entry: entry, Data: data, Timeout: timeout})
+	entry.Solver = entry.Reference
+	reference = runner.Run(ctx, runner.Job{Entry: entry, Data: data, Timeout: timeout})
+
+	if reference.TimedOut() || optimized.TimedOut() {
+		return reference, optimized, true
+	}
+
+	var panicked *runner.PanicError
+	if errors.As(reference.Err, &panicked) || errors.As(optimized.Err, &panicked) {
+		return reference, optimized, false
+	}
+	if reference.Err != nil || optimized.Err != nil {
+		return reference, optimized, reference.Err != nil && optimized.Err != nil
+	}
+	return reference, optimized, reference.Answer == optimized.Answer
+}
+
+// Write は m を人が読める形で w に書く
+func Write(w io.Writer, m *Mismatch) error {
+	lines := len(splitLines(m.Input))
+	original := len(splitLines(m.Original))
+
+	_, err := fmt.Fprintf(w, "day%d part%d: reference and optimized solvers disagree (seed %d, size %d)\n", m.Day, m.Part, m.Seed, m.Size)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "input minimized from %d to %d line(s):\n", original, lines)
+	w.Write(m.Input)
+	fmt.Fprintf(w, "reference: %s\n", outcome(m.Reference))
+	_, err = fmt.Fprintf(w, "optimized: %s\n", outcome(m.Optimized))
+	return err
+}
+
+func outcome(result runner.Result) string {
+	if result.Err != nil {
+		return "error: " + result.Err.Error()
+	}
+	return result.Answer.String()
+}
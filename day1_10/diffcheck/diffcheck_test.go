@@ -0,0 +1,108 @@
+package diffcheck
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"Aoc2022/days"
+	"Aoc2022/solver"
+)
+
+func TestMinimize(t *testing.T) {
+	input := []byte("alpha\nbravo\ncharlie 1 delta\necho\nfoxtrot 2\n")
+
+	// 1 と 2 が両方残っていれば失敗する
+	fails := func(candidate []byte) bool {
+		return bytes.Contains(candidate, []byte("1")) && bytes.Contains(candidate, []byte("2"))
+	}
+
+	got := string(Minimize(input, fails))
+	if got != "1\n2\n" {
+		t.Errorf("got %q, want %q", got, "1\n2\n")
+	}
+}
+
+func TestMinimizeColumns(t *testing.T) {
+	input := []byte("1234\n5678\n9012\n")
+
+	// 盤面の形が崩れると失敗しない
+	fails := func(candidate []byte) bool {
+		width, ok := rectangular(splitLines(candidate))
+		return ok && 2 <= width && bytes.Contains(candidate, []byte("6")) && bytes.Contains(candidate, []byte("0"))
+	}
+
+	got := string(Minimize(input, fails))
+	if got != "56\n90\n" {
+		t.Errorf("got %q, want %q", got, "56\n90\n")
+	}
+}
+
+func TestMinimizeEmpty(t *testing.T) {
+	got := Minimize([]byte("a\nb\n"), func([]byte) bool { return true })
+	if len(got) != 0 {
+		t.Errorf("got %q, want an empty input", got)
+	}
+}
+
+// 入力に 7 を含む行があると 1 だけずれる解法
+func lines(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	data, _ := io.ReadAll(r)
+	return solver.Int(strings.Count(string(data), "\n")), nil
+}
+
+func buggyLines(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	data, _ := io.ReadAll(r)
+	count := strings.Count(string(data), "\n")
+	if strings.Contains(string(data), "7") {
+		count++
+	}
+	return solver.Int(count), nil
+}
+
+func panicking(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	panic("boom")
+}
+
+func TestCheck(t *testing.T) {
+	entry := days.Entry{Day: 1, Part: 1, Solver: solver.Func(buggyLines), Reference: solver.Func(lines)}
+
+	if _, _, same := Check(context.Background(), entry, []byte("1\n2\n"), 0); !same {
+		t.Error("expected the solvers to agree")
+	}
+
+	reference, optimized, same := Check(context.Background(), entry, []byte("1\n7\n"), 0)
+	if same {
+		t.Fatal("expected the solvers to disagree")
+	}
+	if reference.Answer != solver.Int(2) || optimized.Answer != solver.Int(3) {
+		t.Errorf("got reference %v, optimized %v", reference.Answer, optimized.Answer)
+	}
+
+	entry.Solver = solver.Func(panicking)
+	if _, optimized, same := Check(context.Background(), entry, nil, 0); same || optimized.Err == nil {
+		t.Error("expected a panic to count as a disagreement")
+	}
+}
+
+func TestRunAgrees(t *testing.T) {
+	for _, day := range days.ReferenceDays() {
+		mismatch, err := Run(context.Background(), day, Options{N: 30, Seed: 1, MaxSize: 15})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mismatch != nil {
+			var buf bytes.Buffer
+			Write(&buf, mismatch)
+			t.Errorf("day%d:\n%s", day, buf.String())
+		}
+	}
+}
+
+func TestRunWithoutReference(t *testing.T) {
+	if _, err := Run(context.Background(), 1, Options{N: 1}); err == nil {
+		t.Error("expected an error for a day without a reference solver")
+	}
+}
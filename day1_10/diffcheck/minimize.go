@@ -0,0 +1,123 @@
+package diffcheck
+
+import (
+	"strings"
+)
+
+// Minimize は fails を満たしたまま input をできるだけ小さくする.
+// 行を取り除けるだけ取り除き, 盤面のように全ての行の長さが同じなら列も取り除く.
+// 最後に残った各行の文字を取り除く
+func Minimize(input []byte, fails func([]byte) bool) []byte {
+	lines := splitLines(input)
+	lines = reduce(lines, func(candidate []string) bool {
+		return fails(joinLines(candidate))
+	})
+
+	if width, ok := rectangular(lines); ok {
+		columns := make([]int, width)
+		for idx := range columns {
+			columns[idx] = idx
+		}
+		columns = reduce(columns, func(candidate []int) bool {
+			return fails(joinLines(project(lines, candidate)))
+		})
+		lines = project(lines, columns)
+	}
+
+	for idx := range lines {
+		chars := strings.Split(lines[idx], "")
+		chars = reduce(chars, func(candidate []string) bool {
+			replaced := append([]string{}, lines...)
+			replaced[idx] = strings.Join(candidate, "")
+			return fails(joinLines(replaced))
+		})
+		lines[idx] = strings.Join(chars, "")
+	}
+
+	return joinLines(lines)
+}
+
+// reduce は delta debugging で fails を満たす items の部分列を小さくしていく.
+// items を n 個に分け, どれかを取り除いても fails を満たすなら取り除く. 取り除けなければ分け方を細かくする
+func reduce[T any](items []T, fails func([]T) bool) []T {
+	if len(items) != 0 && fails(items[:0]) {
+		return items[:0]
+	}
+
+	n := 2
+	for 2 <= len(items) {
+		chunk := (len(items) + n - 1) / n
+		removed := false
+
+		for start := 0; start < len(items); start += chunk {
+			end := start + chunk
+			if len(items) < end {
+				end = len(items)
+			}
+
+			complement := append(append([]T{}, items[:start]...), items[end:]...)
+			if fails(complement) {
+				items = complement
+				if 2 < n {
+					n--
+				}
+				removed = true
+				break
+			}
+		}
+
+		if removed {
+			continue
+		}
+		if len(items) <= n {
+			break
+		}
+		n *= 2
+		if len(items) < n {
+			n = len(items)
+		}
+	}
+
+	return items
+}
+
+func splitLines(input []byte) []string {
+	text := strings.TrimSuffix(string(input), "\n")
+	if text == "" {
+		return []string{}
+	}
+	return strings.Split(text, "\n")
+}
+
+func joinLines(lines []string) []byte {
+	if len(lines) == 0 {
+		return []byte{}
+	}
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+// rectangular は全ての行が同じ長さならその長さを返す
+func rectangular(lines []string) (int, bool) {
+	if len(lines) == 0 {
+		return 0, false
+	}
+	for _, line := range lines {
+		if len(line) != len(lines[0]) {
+			return 0, false
+		}
+	}
+	return len(lines[0]), true
+}
+
+// project は各行から columns 番目の文字だけを残す
+func project(lines []string, columns []int) []string {
+	result := make([]string, len(lines))
+	for idx, line := range lines {
+		chars := make([]byte, len(columns))
+		for pos, column := range columns {
+			chars[pos] = line[column]
+		}
+		result[idx] = string(chars)
+	}
+	return result
+}
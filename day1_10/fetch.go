@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"Aoc2022/config"
+	"Aoc2022/days"
+	"Aoc2022/site"
+)
+
+func fetchCommand(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to download the puzzle input for")
+	all := fs.Bool("all", false, "download the input of every registered day that is not cached yet")
+	inputDir := fs.String("inputs", defaultInputDir, "directory to cache dayN.txt in")
+	configPath := fs.String("config", config.DefaultPath(), "config `file` holding the session token")
+	baseURL := fs.String("base-url", "", "site to download from (default from the config file, or "+site.DefaultBaseURL+")")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	targets := []int{}
+	switch {
+	case *all && *day != 0:
+		return errors.New("-all cannot be combined with -day")
+	case *all:
+		targets = days.Days()
+	case *day == 0:
+		return errors.New("-day is required (or use -all)")
+	default:
+		targets = append(targets, *day)
+	}
+
+	client, err := newSiteClient(*configPath, *baseURL)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	for _, day := range targets {
+		path, downloaded, err := client.Fetch(ctx, *inputDir, day)
+		if errors.Is(err, site.ErrNoSession) {
+			return fmt.Errorf("%w: set %s or \"session\" in %s", err, config.SessionEnv, *configPath)
+		}
+		if err != nil {
+			return err
+		}
+		if downloaded {
+			fmt.Printf("day%d: downloaded %s\n", day, path)
+		} else {
+			fmt.Printf("day%d: cached %s\n", day, path)
+		}
+	}
+	return nil
+}
+
+// newSiteClient は設定ファイルの session と取得先でクライアントを作る. baseURL が空でなければ設定より優先する
+func newSiteClient(configPath string, baseURL string) (*site.Client, error) {
+	settings, err := config.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if baseURL == "" {
+		baseURL = settings.BaseURL
+	}
+	return site.New(site.Options{BaseURL: baseURL, Session: settings.Session}), nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"Aoc2022/gen"
+)
+
+func genCommand(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to generate an input for")
+	seed := fs.Int64("seed", 1, "random seed; the same seed and size give the same input")
+	size := fs.Int("size", 0, "input size; its unit depends on the day (default per day, see -list)")
+	output := fs.String("o", "", "output `file` (default standard output)")
+	list := fs.Bool("list", false, "list the days with a generator and the meaning of -size")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	if *list {
+		for _, day := range gen.Days() {
+			generator, _ := gen.Lookup(day)
+			fmt.Printf("day%-2d %s (default %d)\n", day, generator.Unit, generator.DefaultSize)
+		}
+		return nil
+	}
+
+	if *day == 0 {
+		return errors.New("-day is required")
+	}
+	if _, err := gen.Lookup(*day); err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return gen.Generate(os.Stdout, *day, *seed, *size)
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := gen.Generate(file, *day, *seed, *size); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,270 @@
+package gen
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+const (
+	lowercase = "abcdefghijklmnopqrstuvwxyz"
+	uppercase = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+func word(rng *rand.Rand, minLength int, maxLength int) string {
+	result := make([]byte, between(rng, minLength, maxLength))
+	for idx := range result {
+		result[idx] = lowercase[rng.Intn(len(lowercase))]
+	}
+	return string(result)
+}
+
+// day1: エルフごとのカロリーを空行区切りで並べる. Q2 のために 3 人より少なくはしない
+func elves(w *bufio.Writer, rng *rand.Rand, size int) {
+	if size < 3 {
+		size = 3
+	}
+
+	for elf := 0; elf < size; elf++ {
+		if elf != 0 {
+			fmt.Fprintln(w)
+		}
+		for count := between(rng, 1, 15); 0 < count; count-- {
+			fmt.Fprintln(w, between(rng, 1000, 60000))
+		}
+	}
+}
+
+// day2: 相手の手 A~C と自分の手/結果 X~Z
+func rounds(w *bufio.Writer, rng *rand.Rand, size int) {
+	for round := 0; round < size; round++ {
+		fmt.Fprintf(w, "%c %c\n", 'A'+rng.Intn(3), 'X'+rng.Intn(3))
+	}
+}
+
+// day3: 3 人組ごとにバッジを 1 つ決め, 各リュックの前半と後半で共通する品物も 1 つだけにする.
+// size は 3 の倍数に切り上げる
+func rucksacks(w *bufio.Writer, rng *rand.Rand, size int) {
+	items := []byte(lowercase + uppercase)
+
+	for group := 0; group < (size+2)/3; group++ {
+		rng.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
+
+		// 先頭をバッジにして, 残りを 3 人で重ならないように分ける
+		badge := items[0]
+		pools := [][]byte{items[1:18], items[18:35], items[35:52]}
+
+		for _, pool := range pools {
+			fmt.Fprintln(w, rucksack(rng, badge, pool))
+		}
+	}
+}
+
+// badge と pool の品物だけを使い, 前半と後半で共通する品物がちょうど 1 つのリュックを作る
+func rucksack(rng *rand.Rand, badge byte, pool []byte) string {
+	// pool の先頭をどちらにも入る品物の候補にして, 残りを前半用と後半用に分ける
+	shared := pool[0]
+	if rng.Intn(4) == 0 {
+		shared = badge
+	}
+	split := between(rng, 2, len(pool)-2)
+	firstOnly := pool[1:split]
+	latterOnly := pool[split:]
+
+	length := between(rng, 4, 24)
+	first := []byte{shared, badge}
+	for len(first) < length {
+		first = append(first, firstOnly[rng.Intn(len(firstOnly))])
+	}
+	latter := []byte{shared}
+	for len(latter) < length {
+		latter = append(latter, latterOnly[rng.Intn(len(latterOnly))])
+	}
+
+	rng.Shuffle(len(first), func(i, j int) { first[i], first[j] = first[j], first[i] })
+	rng.Shuffle(len(latter), func(i, j int) { latter[i], latter[j] = latter[j], latter[i] })
+	return string(first) + string(latter)
+}
+
+// day4: 区間の組. 重なり方がばらけるように区間は短めにする
+func pairs(w *bufio.Writer, rng *rand.Rand, size int) {
+	section := func() (int, int) {
+		start := between(rng, 1, 99)
+		return start, between(rng, start, start+rng.Intn(30))
+	}
+
+	for pair := 0; pair < size; pair++ {
+		firstStart, firstEnd := section()
+		latterStart, latterEnd := section()
+		fmt.Fprintf(w, "%d-%d,%d-%d\n", firstStart, firstEnd, latterStart, latterEnd)
+	}
+}
+
+// day5: クレートの図と手順. 手順を実行している間にどの stack も空にならないようにする
+func procedures(w *bufio.Writer, rng *rand.Rand, size int) {
+	stacks := make([][]byte, between(rng, 3, 9))
+	highest := 0
+	for idx := range stacks {
+		for height := between(rng, 2, 8); 0 < height; height-- {
+			stacks[idx] = append(stacks[idx], uppercase[rng.Intn(len(uppercase))])
+		}
+		if highest < len(stacks[idx]) {
+			highest = len(stacks[idx])
+		}
+	}
+
+	for row := highest - 1; 0 <= row; row-- {
+		cells := make([]string, len(stacks))
+		for idx, stack := range stacks {
+			cells[idx] = "   "
+			if row < len(stack) {
+				cells[idx] = "[" + string(stack[row]) + "]"
+			}
+		}
+		fmt.Fprintln(w, strings.Join(cells, " "))
+	}
+	labels := make([]string, len(stacks))
+	for idx := range stacks {
+		labels[idx] = fmt.Sprintf(" %d ", idx+1)
+	}
+	fmt.Fprintln(w, strings.Join(labels, " "))
+	fmt.Fprintln(w)
+
+	for procedure := 0; procedure < size; procedure++ {
+		// 2 個以上積まれている stack から, 1 個は残して動かす
+		from := rng.Intn(len(stacks))
+		for len(stacks[from]) < 2 {
+			from = rng.Intn(len(stacks))
+		}
+		to := rng.Intn(len(stacks) - 1)
+		if from <= to {
+			to++
+		}
+
+		move := between(rng, 1, len(stacks[from])-1)
+		moved := stacks[from][len(stacks[from])-move:]
+		stacks[to] = append(stacks[to], moved...)
+		stacks[from] = stacks[from][:len(stacks[from])-move]
+
+		fmt.Fprintf(w, "move %d from %d to %d\n", move, from+1, to+1)
+	}
+}
+
+// day6: 14 文字未満の文字種で埋めた信号のどこかに, 14 文字全て違う区間を 1 つ入れる
+func signal(w *bufio.Writer, rng *rand.Rand, size int) {
+	if size < 14 {
+		size = 14
+	}
+
+	letters := []byte(lowercase)
+	rng.Shuffle(len(letters), func(i, j int) { letters[i], letters[j] = letters[j], letters[i] })
+	alphabet := letters[:between(rng, 1, 13)]
+
+	result := make([]byte, size)
+	for idx := range result {
+		result[idx] = alphabet[rng.Intn(len(alphabet))]
+	}
+
+	rng.Shuffle(len(letters), func(i, j int) { letters[i], letters[j] = letters[j], letters[i] })
+	copy(result[rng.Intn(size-13):], letters[:14])
+
+	fmt.Fprintln(w, string(result))
+}
+
+type directory struct {
+	name     string
+	children []*directory
+	files    []string
+}
+
+// day7: ランダムな木を作り, 深さ優先に cd と ls でたどった記録を書く
+func transcript(w *bufio.Writer, rng *rand.Rand, size int) {
+	root := &directory{name: "/"}
+	all := []*directory{root}
+	names := map[*directory]map[string]bool{root: {}}
+
+	for len(all) < size {
+		parent := all[rng.Intn(len(all))]
+		name := word(rng, 1, 8)
+		if names[parent][name] {
+			continue
+		}
+		names[parent][name] = true
+
+		child := &directory{name: name}
+		parent.children = append(parent.children, child)
+		all = append(all, child)
+		names[child] = map[string]bool{}
+	}
+
+	for _, dir := range all {
+		for count := rng.Intn(6); 0 < count; count-- {
+			name := word(rng, 1, 8)
+			if rng.Intn(2) == 0 {
+				name += "." + word(rng, 1, 3)
+			}
+			if names[dir][name] {
+				continue
+			}
+			names[dir][name] = true
+			dir.files = append(dir.files, fmt.Sprintf("%d %s", between(rng, 1, 300000), name))
+		}
+	}
+
+	var visit func(dir *directory)
+	visit = func(dir *directory) {
+		fmt.Fprintln(w, "$ cd", dir.name)
+		fmt.Fprintln(w, "$ ls")
+
+		listing := append([]string{}, dir.files...)
+		for _, child := range dir.children {
+			listing = append(listing, "dir "+child.name)
+		}
+		rng.Shuffle(len(listing), func(i, j int) { listing[i], listing[j] = listing[j], listing[i] })
+		for _, entry := range listing {
+			fmt.Fprintln(w, entry)
+		}
+
+		for _, child := range dir.children {
+			visit(child)
+			fmt.Fprintln(w, "$ cd ..")
+		}
+	}
+	visit(root)
+}
+
+// day8: size x size の木の高さ
+func forest(w *bufio.Writer, rng *rand.Rand, size int) {
+	row := make([]byte, size)
+	for y := 0; y < size; y++ {
+		for x := range row {
+			row[x] = byte('0' + rng.Intn(10))
+		}
+		fmt.Fprintln(w, string(row))
+	}
+}
+
+// day9: 頭の動き
+func motions(w *bufio.Writer, rng *rand.Rand, size int) {
+	for motion := 0; motion < size; motion++ {
+		fmt.Fprintf(w, "%c %d\n", "UDLR"[rng.Intn(4)], between(rng, 1, 20))
+	}
+}
+
+// day10: size サイクル以上かかるプログラム. 画面を埋めるため 240 サイクルより短くはしない
+func program(w *bufio.Writer, rng *rand.Rand, size int) {
+	if size < 240 {
+		size = 240
+	}
+
+	for cycle := 0; cycle < size; {
+		if rng.Intn(3) == 0 {
+			fmt.Fprintln(w, "noop")
+			cycle++
+			continue
+		}
+		fmt.Fprintln(w, "addx", between(rng, -20, 20))
+		cycle += 2
+	}
+}
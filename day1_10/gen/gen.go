@@ -0,0 +1,90 @@
+package gen
+
+/*
+gen
+
+各日の問題の形式に沿った入力をランダムに作る.
+同じ seed と size からは同じ入力ができる.
+size の意味は日ごとに違う (エルフの人数, 行数, 盤面の一辺など).
+*/
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+)
+
+var ErrUnknownDay = errors.New("no generator for day")
+
+// Generator は 1 日分の入力の作り方
+type Generator struct {
+	// size を省略したときの大きさ
+	DefaultSize int
+	// size が何の数か
+	Unit     string
+	generate func(w *bufio.Writer, rng *rand.Rand, size int)
+}
+
+var generators = map[int]Generator{
+	1:  {1000, "elves", elves},
+	2:  {10000, "rounds", rounds},
+	3:  {3000, "rucksacks", rucksacks},
+	4:  {1000, "pairs", pairs},
+	5:  {500, "moves", procedures},
+	6:  {4096, "characters", signal},
+	7:  {200, "directories", transcript},
+	8:  {99, "trees per side", forest},
+	9:  {2000, "motions", motions},
+	10: {240, "cycles", program},
+}
+
+// Days は入力を作れる日を昇順で返す
+func Days() []int {
+	result := make([]int, 0, len(generators))
+	for day := range generators {
+		result = append(result, day)
+	}
+	sort.Ints(result)
+	return result
+}
+
+func Lookup(day int) (Generator, error) {
+	generator, exists := generators[day]
+	if !exists {
+		return Generator{}, fmt.Errorf("%w %d", ErrUnknownDay, day)
+	}
+	return generator, nil
+}
+
+// Generate は day 日目の入力を w に書く. size が 0 以下なら DefaultSize を使う
+func Generate(w io.Writer, day int, seed int64, size int) error {
+	generator, err := Lookup(day)
+	if err != nil {
+		return err
+	}
+	if size <= 0 {
+		size = generator.DefaultSize
+	}
+
+	buffered := bufio.NewWriter(w)
+	generator.generate(buffered, rand.New(rand.NewSource(seed)), size)
+	return buffered.Flush()
+}
+
+// Bytes は Generate の結果をまとめて返す
+func Bytes(day int, seed int64, size int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Generate(&buf, day, seed, size); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// between は lo 以上 hi 以下の乱数
+func between(rng *rand.Rand, lo int, hi int) int {
+	return lo + rng.Intn(hi-lo+1)
+}
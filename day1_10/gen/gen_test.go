@@ -0,0 +1,84 @@
+package gen_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"Aoc2022/days"
+	"Aoc2022/gen"
+)
+
+// 作った入力はどの日の解法でもエラーにならない
+func TestGeneratedInputsAreSolvable(t *testing.T) {
+	for _, day := range gen.Days() {
+		entries, err := days.Parts(day)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for seed := int64(1); seed <= 5; seed++ {
+			for _, size := range []int{1, 3, 0} {
+				data, err := gen.Bytes(day, seed, size)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for _, entry := range entries {
+					if _, err := entry.Solver.Solve(context.Background(), bytes.NewReader(data)); err != nil {
+						t.Errorf("day%d part%d seed %d size %d: %v", day, entry.Part, seed, size, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+// day6 の信号は 1 行なので, 大きくすると input.Scanner の行の上限を超える
+func TestLargeSignal(t *testing.T) {
+	data, err := gen.Bytes(6, 1, 2000000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := days.Parts(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		got, err := entry.Solver.Solve(context.Background(), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("part%d: %v", entry.Part, err)
+		}
+		want, err := entry.Reference.Solve(context.Background(), bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("part%d reference: %v", entry.Part, err)
+		}
+		if got != want {
+			t.Errorf("part%d: got %v, want %v", entry.Part, got, want)
+		}
+	}
+}
+
+func TestSameSeedSameInput(t *testing.T) {
+	first, err := gen.Bytes(7, 42, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := gen.Bytes(7, 42, 50)
+	other, _ := gen.Bytes(7, 43, 50)
+
+	if !bytes.Equal(first, second) {
+		t.Error("same seed generated different inputs")
+	}
+	if bytes.Equal(first, other) {
+		t.Error("different seeds generated the same input")
+	}
+}
+
+func TestUnknownDay(t *testing.T) {
+	if _, err := gen.Bytes(26, 1, 0); !errors.Is(err, gen.ErrUnknownDay) {
+		t.Errorf("got %v, want ErrUnknownDay", err)
+	}
+}
@@ -1,8 +1,3 @@
 module Aoc2022
 
 go 1.18
-
-require (
-	github.com/emirpasic/gods v1.18.1 // indirect
-	github.com/golang-collections/collections v0.0.0-20130729185459-604e922904d3 // indirect
-)
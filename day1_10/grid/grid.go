@@ -0,0 +1,119 @@
+package grid
+
+import (
+	"fmt"
+)
+
+// Grid は幅と高さが決まっている盤面. 左上が (0, 0)
+type Grid[T any] struct {
+	width  int
+	height int
+	cells  []T
+}
+
+func New[T any](width int, height int) *Grid[T] {
+	if width < 0 || height < 0 {
+		panic(fmt.Sprintf("grid: invalid size %dx%d", width, height))
+	}
+	return &Grid[T]{width: width, height: height, cells: make([]T, width*height)}
+}
+
+// FromRows は各行の要素をそのまま並べた盤面を返す. 行の長さは揃っている必要がある
+func FromRows[T any](rows [][]T) (*Grid[T], error) {
+	if len(rows) == 0 {
+		return New[T](0, 0), nil
+	}
+
+	g := New[T](len(rows[0]), len(rows))
+	for y, row := range rows {
+		if len(row) != g.width {
+			return nil, fmt.Errorf("row %d has width %d, want %d", y, len(row), g.width)
+		}
+		copy(g.cells[y*g.width:], row)
+	}
+	return g, nil
+}
+
+func (g *Grid[T]) Width() int {
+	return g.width
+}
+
+func (g *Grid[T]) Height() int {
+	return g.height
+}
+
+func (g *Grid[T]) Bounds() Rect {
+	return Size(g.width, g.height)
+}
+
+func (g *Grid[T]) In(p Point) bool {
+	return g.Bounds().Contains(p)
+}
+
+// At は p のマスの値を返す. 盤面の外なら panic する
+func (g *Grid[T]) At(p Point) T {
+	return g.cells[g.index(p)]
+}
+
+// Ref は p のマスを直接書き換えるためのポインタを返す
+func (g *Grid[T]) Ref(p Point) *T {
+	return &g.cells[g.index(p)]
+}
+
+func (g *Grid[T]) Set(p Point, value T) {
+	g.cells[g.index(p)] = value
+}
+
+func (g *Grid[T]) Fill(value T) {
+	for idx := range g.cells {
+		g.cells[idx] = value
+	}
+}
+
+func (g *Grid[T]) index(p Point) int {
+	if !g.In(p) {
+		panic(fmt.Sprintf("grid: %v out of bounds %dx%d", p, g.width, g.height))
+	}
+	return p.Y*g.width + p.X
+}
+
+// Each は全てのマスを上の行から順に f に渡す. f が false を返すとそこで止める
+func (g *Grid[T]) Each(f func(p Point, value T) bool) {
+	for y := 0; y < g.height; y++ {
+		for x := 0; x < g.width; x++ {
+			if !f(Point{x, y}, g.cells[y*g.width+x]) {
+				return
+			}
+		}
+	}
+}
+
+// Row は y 行目のマスを左から順に f に渡す
+func (g *Grid[T]) Row(y int, f func(p Point, value T) bool) {
+	g.Ray(Point{-1, y}, Right, f)
+}
+
+// Column は x 列目のマスを上から順に f に渡す
+func (g *Grid[T]) Column(x int, f func(p Point, value T) bool) {
+	g.Ray(Point{x, -1}, Down, f)
+}
+
+// Ray は from から direction に進んだマスを, 盤面の端まで順に f に渡す. from 自身は含まない
+func (g *Grid[T]) Ray(from Point, direction Point, f func(p Point, value T) bool) {
+	if direction == (Point{}) {
+		return
+	}
+
+	for p := from.Add(direction); g.In(p); p = p.Add(direction) {
+		if !f(p, g.At(p)) {
+			return
+		}
+	}
+}
+
+// Render は各マスを glyph で 1 文字にして, 上の行から順に返す
+func (g *Grid[T]) Render(glyph func(p Point, value T) rune) []string {
+	return Render(g.Bounds(), func(p Point) rune {
+		return glyph(p, g.At(p))
+	})
+}
@@ -0,0 +1,107 @@
+package grid
+
+import (
+	"strings"
+	"testing"
+)
+
+func parseDigits(t *testing.T, text string) *Grid[int] {
+	rows := [][]int{}
+	for _, line := range strings.Split(text, "\n") {
+		row := []int{}
+		for _, char := range line {
+			row = append(row, int(char-'0'))
+		}
+		rows = append(rows, row)
+	}
+
+	g, err := FromRows(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func collect(iterate func(f func(p Point, value int) bool)) []int {
+	result := []int{}
+	iterate(func(_ Point, value int) bool {
+		result = append(result, value)
+		return true
+	})
+	return result
+}
+
+func equal(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGrid(t *testing.T) {
+	g := parseDigits(t, "123\n456")
+
+	if g.Width() != 3 || g.Height() != 2 {
+		t.Fatalf("got %dx%d", g.Width(), g.Height())
+	}
+	if got := g.At(Point{2, 1}); got != 6 {
+		t.Errorf("At: got %d, want 6", got)
+	}
+
+	g.Set(Point{0, 0}, 9)
+	*g.Ref(Point{1, 0}) += 10
+	if got := g.Render(func(_ Point, value int) rune { return rune('0' + value%10) }); strings.Join(got, "\n") != "923\n456" {
+		t.Errorf("Render: got %q", got)
+	}
+
+	if g.In(Point{3, 0}) || g.In(Point{0, -1}) || !g.In(Point{2, 1}) {
+		t.Error("unexpected In result")
+	}
+}
+
+func TestGridIterators(t *testing.T) {
+	g := parseDigits(t, "123\n456\n789")
+
+	if got := collect(func(f func(Point, int) bool) { g.Row(1, f) }); !equal(got, []int{4, 5, 6}) {
+		t.Errorf("Row: got %v", got)
+	}
+	if got := collect(func(f func(Point, int) bool) { g.Column(2, f) }); !equal(got, []int{3, 6, 9}) {
+		t.Errorf("Column: got %v", got)
+	}
+	if got := collect(func(f func(Point, int) bool) { g.Ray(Point{1, 1}, Up.Add(Left), f) }); !equal(got, []int{1}) {
+		t.Errorf("Ray: got %v", got)
+	}
+	if got := collect(func(f func(Point, int) bool) { g.Ray(Point{2, 1}, Left, f) }); !equal(got, []int{5, 4}) {
+		t.Errorf("Ray: got %v", got)
+	}
+
+	// false を返したところで止まる
+	visited := 0
+	g.Each(func(_ Point, value int) bool {
+		visited++
+		return value < 5
+	})
+	if visited != 5 {
+		t.Errorf("Each: visited %d cells, want 5", visited)
+	}
+}
+
+func TestFromRowsRagged(t *testing.T) {
+	if _, err := FromRows([][]int{{1, 2}, {3}}); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestOutOfBounds(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic")
+		}
+	}()
+	New[int](2, 2).At(Point{2, 0})
+}
@@ -0,0 +1,97 @@
+package grid
+
+/*
+grid
+
+2 次元の盤面を扱うための道具.
+
+* Point: 座標. X は右, Y は下に向かって増える
+* Rect: 座標の範囲
+* Grid: 幅と高さが決まっている盤面
+* Sparse: 使ったマスだけを持つ盤面. 範囲は使ったマスに合わせて広がる
+*/
+
+// Point は盤面上の座標
+type Point struct {
+	X int
+	Y int
+}
+
+// 画面と同じく Y が下に向かって増える向き
+var (
+	Up    = Point{0, -1}
+	Down  = Point{0, 1}
+	Left  = Point{-1, 0}
+	Right = Point{1, 0}
+
+	// 上から時計回り
+	Directions4 = []Point{Up, Right, Down, Left}
+	// 上から時計回り. 斜めを含む
+	Directions8 = []Point{
+		Up, Up.Add(Right), Right, Down.Add(Right),
+		Down, Down.Add(Left), Left, Up.Add(Left),
+	}
+)
+
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+func (p Point) Sub(q Point) Point {
+	return Point{p.X - q.X, p.Y - q.Y}
+}
+
+// Sign は各成分を -1, 0, 1 に丸めた値を返す
+func (p Point) Sign() Point {
+	return Point{sign(p.X), sign(p.Y)}
+}
+
+// Manhattan は p と q のマンハッタン距離
+func (p Point) Manhattan(q Point) int {
+	return abs(p.X-q.X) + abs(p.Y-q.Y)
+}
+
+// Chebyshev は p と q のチェビシェフ距離. 斜めも 1 歩と数える
+func (p Point) Chebyshev(q Point) int {
+	diffX := abs(p.X - q.X)
+	diffY := abs(p.Y - q.Y)
+	if diffX < diffY {
+		return diffY
+	}
+	return diffX
+}
+
+// Neighbours4 は上下左右に隣接する座標を返す
+func (p Point) Neighbours4() []Point {
+	return p.neighbours(Directions4)
+}
+
+// Neighbours8 は斜めを含めて隣接する座標を返す
+func (p Point) Neighbours8() []Point {
+	return p.neighbours(Directions8)
+}
+
+func (p Point) neighbours(directions []Point) []Point {
+	result := make([]Point, len(directions))
+	for idx, direction := range directions {
+		result[idx] = p.Add(direction)
+	}
+	return result
+}
+
+func sign(x int) int {
+	if 0 < x {
+		return 1
+	}
+	if x < 0 {
+		return -1
+	}
+	return 0
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
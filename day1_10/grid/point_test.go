@@ -0,0 +1,46 @@
+package grid
+
+import (
+	"testing"
+)
+
+func TestPointDistance(t *testing.T) {
+	p := Point{1, 2}
+	q := Point{-2, 4}
+
+	if got := p.Manhattan(q); got != 5 {
+		t.Errorf("Manhattan: got %d, want 5", got)
+	}
+	if got := p.Chebyshev(q); got != 3 {
+		t.Errorf("Chebyshev: got %d, want 3", got)
+	}
+	if got := q.Sub(p).Sign(); got != (Point{-1, 1}) {
+		t.Errorf("Sign: got %v", got)
+	}
+}
+
+func TestNeighbours(t *testing.T) {
+	p := Point{5, 5}
+
+	four := p.Neighbours4()
+	want := []Point{{5, 4}, {6, 5}, {5, 6}, {4, 5}}
+	if len(four) != len(want) {
+		t.Fatalf("got %v", four)
+	}
+	for idx := range want {
+		if four[idx] != want[idx] {
+			t.Errorf("Neighbours4: got %v, want %v", four, want)
+			break
+		}
+	}
+
+	eight := p.Neighbours8()
+	if len(eight) != 8 {
+		t.Fatalf("got %v", eight)
+	}
+	for _, neighbour := range eight {
+		if p.Chebyshev(neighbour) != 1 {
+			t.Errorf("%v is not adjacent to %v", neighbour, p)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package grid
+
+// Rect は Min を含み Max を含まない範囲
+type Rect struct {
+	Min Point
+	Max Point
+}
+
+// Size は (0, 0) から width x height の範囲を返す
+func Size(width int, height int) Rect {
+	return Rect{Max: Point{width, height}}
+}
+
+func (r Rect) Width() int {
+	return r.Max.X - r.Min.X
+}
+
+func (r Rect) Height() int {
+	return r.Max.Y - r.Min.Y
+}
+
+func (r Rect) Empty() bool {
+	return r.Width() <= 0 || r.Height() <= 0
+}
+
+func (r Rect) Contains(p Point) bool {
+	return r.Min.X <= p.X && p.X < r.Max.X &&
+		r.Min.Y <= p.Y && p.Y < r.Max.Y
+}
+
+// Extend は p を含むように広げた範囲を返す
+func (r Rect) Extend(p Point) Rect {
+	if r.Empty() {
+		return Rect{Min: p, Max: p.Add(Point{1, 1})}
+	}
+
+	if p.X < r.Min.X {
+		r.Min.X = p.X
+	}
+	if p.Y < r.Min.Y {
+		r.Min.Y = p.Y
+	}
+	if r.Max.X <= p.X {
+		r.Max.X = p.X + 1
+	}
+	if r.Max.Y <= p.Y {
+		r.Max.Y = p.Y + 1
+	}
+	return r
+}
+
+// Render は範囲内のマスを上の行から順に 1 行の文字列にする
+func Render(bounds Rect, glyph func(p Point) rune) []string {
+	rows := make([]string, 0, bounds.Height())
+	line := make([]rune, 0, bounds.Width())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		line = line[:0]
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			line = append(line, glyph(Point{x, y}))
+		}
+		rows = append(rows, string(line))
+	}
+	return rows
+}
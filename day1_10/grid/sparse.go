@@ -0,0 +1,60 @@
+package grid
+
+// Sparse は値を置いたマスだけを持つ盤面. 座標は負でもよい
+type Sparse[T any] struct {
+	cells  map[Point]T
+	bounds Rect
+}
+
+func NewSparse[T any]() *Sparse[T] {
+	return &Sparse[T]{cells: map[Point]T{}}
+}
+
+func (s *Sparse[T]) Set(p Point, value T) {
+	s.cells[p] = value
+	s.bounds = s.bounds.Extend(p)
+}
+
+func (s *Sparse[T]) Get(p Point) (T, bool) {
+	value, ok := s.cells[p]
+	return value, ok
+}
+
+func (s *Sparse[T]) Has(p Point) bool {
+	_, ok := s.cells[p]
+	return ok
+}
+
+// Delete は p のマスを取り除く. 範囲は縮めない
+func (s *Sparse[T]) Delete(p Point) {
+	delete(s.cells, p)
+}
+
+func (s *Sparse[T]) Len() int {
+	return len(s.cells)
+}
+
+// Bounds はこれまでに値を置いた全てのマスを含む範囲
+func (s *Sparse[T]) Bounds() Rect {
+	return s.bounds
+}
+
+// Each は全てのマスを f に渡す. 順番は決まっていない
+func (s *Sparse[T]) Each(f func(p Point, value T) bool) {
+	for p, value := range s.cells {
+		if !f(p, value) {
+			return
+		}
+	}
+}
+
+// Render は Bounds の範囲を上の行から順に返す. 値のないマスは empty にする
+func (s *Sparse[T]) Render(empty rune, glyph func(p Point, value T) rune) []string {
+	return Render(s.bounds, func(p Point) rune {
+		value, ok := s.cells[p]
+		if !ok {
+			return empty
+		}
+		return glyph(p, value)
+	})
+}
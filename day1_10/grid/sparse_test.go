@@ -0,0 +1,55 @@
+package grid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSparse(t *testing.T) {
+	s := NewSparse[rune]()
+	s.Set(Point{-1, 0}, 'a')
+	s.Set(Point{1, 2}, 'b')
+	s.Set(Point{1, 2}, 'c')
+
+	if s.Len() != 2 {
+		t.Errorf("got len %d, want 2", s.Len())
+	}
+	if value, ok := s.Get(Point{1, 2}); !ok || value != 'c' {
+		t.Errorf("Get: got %c, %t", value, ok)
+	}
+	if s.Has(Point{0, 0}) {
+		t.Error("unexpected cell at (0, 0)")
+	}
+
+	want := Rect{Min: Point{-1, 0}, Max: Point{2, 3}}
+	if s.Bounds() != want {
+		t.Errorf("Bounds: got %v, want %v", s.Bounds(), want)
+	}
+
+	got := s.Render('.', func(_ Point, value rune) rune { return value })
+	if strings.Join(got, "\n") != "a..\n...\n..c" {
+		t.Errorf("Render: got %q", got)
+	}
+
+	s.Delete(Point{-1, 0})
+	if s.Has(Point{-1, 0}) || s.Len() != 1 {
+		t.Error("Delete did not remove the cell")
+	}
+}
+
+func TestRectExtend(t *testing.T) {
+	r := Rect{}
+	if !r.Empty() {
+		t.Error("zero Rect should be empty")
+	}
+
+	r = r.Extend(Point{3, 3})
+	if r != (Rect{Min: Point{3, 3}, Max: Point{4, 4}}) {
+		t.Errorf("got %v", r)
+	}
+
+	r = r.Extend(Point{0, 5})
+	if r.Width() != 4 || r.Height() != 3 || !r.Contains(Point{0, 5}) || r.Contains(Point{4, 5}) {
+		t.Errorf("got %v", r)
+	}
+}
@@ -0,0 +1,197 @@
+package input
+
+/*
+input
+
+パズル入力の読み込みに使う共通処理.
+
+* 行番号を数えながら 1 行ずつ読む
+* 空行区切りのグループに分ける
+* 整数や書式 (Sscanf, 正規表現) に沿ったレコードを取り出す
+
+エラーには行番号とその行の内容が含まれる.
+*/
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 1 行の最大長. 上限のない 1 行だけの入力 (day6 の信号) は Scanner を使わずに読む
+const maxLineLength = 1024 * 1024
+
+// Line は入力の 1 行. Number は 1 始まりの行番号
+type Line struct {
+	Number int
+	Text   string
+}
+
+// Error は入力のどの行で起きたかを持つエラー
+type Error struct {
+	Line int
+	Text string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d %q: %v", e.Line, e.Text, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Wrap は err にこの行の位置を付ける. err が nil なら nil
+func (l Line) Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Line: l.Number, Text: l.Text, Err: err}
+}
+
+// Errorf はこの行の位置を持つエラーを作る
+func (l Line) Errorf(format string, args ...interface{}) error {
+	return l.Wrap(fmt.Errorf(format, args...))
+}
+
+func (l Line) IsBlank() bool {
+	return len(strings.TrimSpace(l.Text)) == 0
+}
+
+// Int は行全体を 1 つの整数として読む
+func (l Line) Int() (int, error) {
+	value, err := strconv.Atoi(strings.TrimSpace(l.Text))
+	if err != nil {
+		return 0, l.Wrap(err)
+	}
+	return value, nil
+}
+
+var intPattern = regexp.MustCompile(`-?\d+`)
+
+// Ints は行に含まれる整数を順に取り出す. "move 1 from 2 to 3" なら [1 2 3]
+func (l Line) Ints() ([]int, error) {
+	result := []int{}
+	for _, match := range intPattern.FindAllString(l.Text, -1) {
+		value, err := strconv.Atoi(match)
+		if err != nil {
+			return nil, l.Wrap(err)
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+func (l Line) Fields() []string {
+	return strings.Fields(l.Text)
+}
+
+// Scanf は行全体を format に沿って読む. Sscanf と同じ書式
+func (l Line) Scanf(format string, args ...interface{}) error {
+	return l.Wrap(Sscanf(l.Text, format, args...))
+}
+
+// Match は行全体が pattern に一致するとき, そのサブマッチを返す
+func (l Line) Match(pattern *regexp.Regexp) ([]string, error) {
+	match := pattern.FindStringSubmatch(l.Text)
+	if match == nil || len(match[0]) != len(l.Text) {
+		return nil, l.Errorf("does not match %s", pattern)
+	}
+	return match[1:], nil
+}
+
+// Sscanf は fmt.Sscanf と同じだが, text の末尾に読み残しがあればエラーにする
+func Sscanf(text string, format string, args ...interface{}) error {
+	reader := strings.NewReader(text)
+	if _, err := fmt.Fscanf(reader, format, args...); err != nil {
+		return fmt.Errorf("expected %q: %w", format, err)
+	}
+
+	rest, _ := io.ReadAll(reader)
+	if len(strings.TrimSpace(string(rest))) != 0 {
+		return fmt.Errorf("expected %q: unexpected trailing %q", format, rest)
+	}
+	return nil
+}
+
+// Scanner は行番号を数えながら入力を 1 行ずつ読む.
+// bufio.Scanner と違い, 空行は EOF として扱わない
+type Scanner struct {
+	scanner *bufio.Scanner
+	line    Line
+}
+
+func NewScanner(r io.Reader) *Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+	return &Scanner{scanner: scanner}
+}
+
+// Scan は次の行に進む. 入力の終わりか読み込みエラーなら false
+func (s *Scanner) Scan() bool {
+	if !s.scanner.Scan() {
+		return false
+	}
+	s.line = Line{Number: s.line.Number + 1, Text: strings.TrimSuffix(s.scanner.Text(), "\r")}
+	return true
+}
+
+func (s *Scanner) Line() Line {
+	return s.line
+}
+
+// Err は読み込み中に起きたエラーを返す
+func (s *Scanner) Err() error {
+	err := s.scanner.Err()
+	if errors.Is(err, bufio.ErrTooLong) {
+		return fmt.Errorf("line %d: longer than %d bytes", s.line.Number+1, maxLineLength)
+	}
+	return err
+}
+
+// Lines は全ての行を読む. 末尾の空行は含めない
+func Lines(r io.Reader) ([]Line, error) {
+	scanner := NewScanner(r)
+	lines := []Line{}
+	for scanner.Scan() {
+		lines = append(lines, scanner.Line())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for 0 < len(lines) && lines[len(lines)-1].IsBlank() {
+		lines = lines[:len(lines)-1]
+	}
+	return lines, nil
+}
+
+// Groups は空行で区切られた行のまとまりを読む. 連続した空行は 1 つの区切りとみなす
+func Groups(r io.Reader) ([][]Line, error) {
+	lines, err := Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := [][]Line{}
+	group := []Line{}
+	for _, line := range lines {
+		if line.IsBlank() {
+			if len(group) != 0 {
+				groups = append(groups, group)
+				group = []Line{}
+			}
+			continue
+		}
+		group = append(group, line)
+	}
+	if len(group) != 0 {
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
@@ -0,0 +1,140 @@
+package input
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func texts(lines []Line) []string {
+	result := []string{}
+	for _, line := range lines {
+		result = append(result, line.Text)
+	}
+	return result
+}
+
+func TestLines(t *testing.T) {
+	lines, err := Lines(strings.NewReader("a\r\n\nb\n\n\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 途中の空行は残し, 末尾の空行は除く
+	want := []Line{{1, "a"}, {2, ""}, {3, "b"}}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("got %+v, want %+v", lines, want)
+	}
+}
+
+func TestLinesLongLine(t *testing.T) {
+	long := strings.Repeat("x", 100*1024)
+	lines, err := Lines(strings.NewReader(long + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 1 || lines[0].Text != long {
+		t.Errorf("expected one long line, got %d lines", len(lines))
+	}
+}
+
+func TestGroups(t *testing.T) {
+	groups, err := Groups(strings.NewReader("\n1000\n2000\n\n3000\n\n\n4000\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := [][]string{}
+	for _, group := range groups {
+		got = append(got, texts(group))
+	}
+	want := [][]string{{"1000", "2000"}, {"3000"}, {"4000"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if groups[2][0].Number != 8 {
+		t.Errorf("got line number %d, want 8", groups[2][0].Number)
+	}
+}
+
+func TestLineInt(t *testing.T) {
+	value, err := Line{1, " 42 "}.Int()
+	if err != nil || value != 42 {
+		t.Errorf("got %d, %v", value, err)
+	}
+
+	_, err = Line{7, "4x2"}.Int()
+	var inputErr *Error
+	if !errors.As(err, &inputErr) {
+		t.Fatalf("expected an *Error, got %v", err)
+	}
+	if inputErr.Line != 7 || inputErr.Text != "4x2" {
+		t.Errorf("unexpected error position: %+v", inputErr)
+	}
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Errorf("expected the strconv error to be wrapped, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `line 7 "4x2"`) {
+		t.Errorf("error should mention the line: %v", err)
+	}
+}
+
+func TestLineInts(t *testing.T) {
+	values, err := Line{1, "move 12 from -2 to 3"}.Ints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values, []int{12, -2, 3}) {
+		t.Errorf("got %v", values)
+	}
+}
+
+func TestLineScanf(t *testing.T) {
+	var a, b, c, d int
+	if err := (Line{1, "2-4,6-8"}).Scanf("%d-%d,%d-%d", &a, &b, &c, &d); err != nil {
+		t.Fatal(err)
+	}
+	if a != 2 || b != 4 || c != 6 || d != 8 {
+		t.Errorf("got %d %d %d %d", a, b, c, d)
+	}
+
+	for _, text := range []string{"2-4,6", "2-4,6-8,1", "x-4,6-8", ""} {
+		if err := (Line{3, text}).Scanf("%d-%d,%d-%d", &a, &b, &c, &d); err == nil {
+			t.Errorf("%q: expected an error", text)
+		}
+	}
+}
+
+func TestLineMatch(t *testing.T) {
+	pattern := regexp.MustCompile(`([UDLR]) (\d+)`)
+
+	match, err := Line{1, "R 4"}.Match(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(match, []string{"R", "4"}) {
+		t.Errorf("got %q", match)
+	}
+
+	if _, err := (Line{2, "R 4 extra"}).Match(pattern); err == nil {
+		t.Error("expected a partial match to fail")
+	}
+}
+
+func TestScannerKeepsGoingAfterBlankLines(t *testing.T) {
+	scanner := NewScanner(strings.NewReader("a\n\nb"))
+	got := []string{}
+	for scanner.Scan() {
+		got = append(got, scanner.Line().Text)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, []string{"a", "", "b"}) {
+		t.Errorf("got %q", got)
+	}
+}
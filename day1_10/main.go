@@ -1,11 +1,90 @@
 package main
 
+/*
+aoc
+
+Advent of Code 2022 の解法を実行するコマンド
+
+	aoc run -day 7 -part 2 -input path
+	aoc run -all -timeout 5s
+	aoc run -day 9 -part 2 -set day9.rope=20
+	aoc run -day 8 -part 1 -reference -cpuprofile cpu.pprof
+	aoc run -day 9 -part 1 -input path -reference -trace-level verbose
+	aoc params
+	aoc watch -day 8 -part 2
+	aoc batch -day 4 -dir inputs/day4/ -j 8
+	aoc bench -day 8 -save baseline.json
+	aoc verify
+	aoc serve -addr localhost:8080
+	aoc gen -day 7 -seed 1 -size 10000 -o big.txt
+	aoc diffcheck -day 8 -n 1000
+	aoc new -day 11 -title "Monkey in the Middle"
+	aoc fetch -day 11
+	aoc submit -day 11 -part 1
+*/
+
 import (
-	"Aoc2022/days/day1"
+	"errors"
+	"flag"
 	"fmt"
+	"os"
+	"sort"
 )
 
+type command struct {
+	run     func(args []string) error
+	summary string
+}
+
+var commands = map[string]command{
+	"run":       {runCommand, "run solvers for a day/part or for every registered day"},
+	"bench":     {benchCommand, "benchmark solvers and compare with a saved baseline"},
+	"verify":    {verifyCommand, "check every solver against the known answers"},
+	"serve":     {serveCommand, "serve the solvers over HTTP"},
+	"gen":       {genCommand, "generate a large random input for a day"},
+	"diffcheck": {diffcheckCommand, "compare optimized solvers with their reference on generated inputs"},
+	"new":       {newCommand, "create the package, tests and input file for a new day"},
+	"fetch":     {fetchCommand, "download and cache the puzzle input for a day"},
+	"submit":    {submitCommand, "submit a solver's answer and record the outcome"},
+	"params":    {paramsCommand, "list the puzzle constants that -set and the config file can change"},
+	"watch":     {watchCommand, "re-run a day and its example tests whenever its source or input changes"},
+	"batch":     {batchCommand, "run a day on every input in a directory and check them against *.expected"},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: aoc <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].summary)
+	}
+}
+
 func main() {
-	fmt.Println("started.")
-	day1.PartOne()
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, exists := commands[os.Args[1]]
+	if !exists {
+		fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	err := cmd.run(os.Args[2:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "aoc:", err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"Aoc2022/scaffold"
+)
+
+func newCommand(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to create a package for")
+	title := fs.String("title", "", "puzzle title shown in listings (default \"Day N\")")
+	root := fs.String("root", ".", "module `directory` containing go.mod and days/")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	if *day == 0 {
+		return errors.New("-day is required")
+	}
+
+	created, err := scaffold.Create(*root, *day, *title)
+	for _, path := range created {
+		fmt.Println(path)
+	}
+	return err
+}
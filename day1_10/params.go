@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"Aoc2022/config"
+	"Aoc2022/params"
+)
+
+// settings は -set key=value を何度でも受け付ける. 後に書いたものが勝つ
+type settings map[string]string
+
+func (s settings) String() string {
+	pairs := make([]string, 0, len(s))
+	for key, value := range s {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (s settings) Set(value string) error {
+	key, value, found := strings.Cut(value, "=")
+	if !found || key == "" {
+		return fmt.Errorf("want key=value, got %q", value)
+	}
+	s[key] = value
+	return nil
+}
+
+// paramFlags は設定ファイルと -set のフラグを fs に足す
+func paramFlags(fs *flag.FlagSet) (configPath *string, overrides settings) {
+	configPath = fs.String("config", config.DefaultPath(), "config `file` whose \"params\" override the puzzle constants")
+	overrides = settings{}
+	fs.Var(overrides, "set", "override a puzzle constant as `key=value` (repeatable; see aoc params)")
+	return configPath, overrides
+}
+
+// loadParams は設定ファイルの params に -set を重ねて確かめる
+func loadParams(configPath string, overrides settings) (params.Values, error) {
+	settings, err := config.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := map[string]string{}
+	for key, value := range settings.Params {
+		merged[key] = value.String()
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+
+	values, err := params.Parse(merged)
+	if err != nil {
+		return nil, fmt.Errorf("%w (see aoc params)", err)
+	}
+	return values, nil
+}
+
+func paramsCommand(args []string) error {
+	fs := flag.NewFlagSet("params", flag.ContinueOnError)
+	configPath, overrides := paramFlags(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	values, err := loadParams(*configPath, overrides)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, p := range params.All() {
+		value := fmt.Sprint(values.Int(p))
+		if values.Int(p) != p.Default {
+			value += fmt.Sprintf(" (default %d)", p.Default)
+		}
+		usage := p.Usage
+		if p.Max != math.MaxInt {
+			usage += fmt.Sprintf(" (%d-%d)", p.Min, p.Max)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", p.Key, value, usage)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,145 @@
+package params
+
+/*
+params
+
+各日の問題の定数 (上位何人, マーカーの長さ, ロープの長さなど) を実行時に変えられるようにする.
+各日のパッケージが既定値と下限, 上限を付けて宣言し, 解法は ctx に載った値を読む.
+ctx に値がなければ既定値を使うので, 何も指定しなければ問題文どおりに解く.
+
+	var top = params.NewInt("day1.top", 3, 1, math.MaxInt, "number of elves summed in part two")
+
+	func PartTwo(ctx context.Context, r io.Reader) (solver.Answer, error) {
+		n := top.Get(ctx)
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrUnknownKey = errors.New("unknown parameter")
+
+// Int は整数のパラメータ 1 つ
+type Int struct {
+	Key     string
+	Default int
+	// これより小さい値は受け付けない
+	Min int
+	// これより大きい値は受け付けない. 盤面やロープの大きさのように, 大きすぎると確保できないものに付ける
+	Max   int
+	Usage string
+}
+
+var (
+	registry = map[string]*Int{}
+	rules    = []func(Values) error{}
+)
+
+// NewInt はパラメータを宣言する. パッケージ変数の初期化で呼ぶ. 上限がなければ max は math.MaxInt
+func NewInt(key string, def int, min int, max int, usage string) *Int {
+	if _, exists := registry[key]; exists {
+		panic("params: duplicate key " + key)
+	}
+	if def < min {
+		panic(fmt.Sprintf("params: default of %s is less than %d", key, min))
+	}
+	if max < def {
+		panic(fmt.Sprintf("params: default of %s is greater than %d", key, max))
+	}
+	p := &Int{Key: key, Default: def, Min: min, Max: max, Usage: usage}
+	registry[key] = p
+	return p
+}
+
+// AddRule は複数のパラメータにまたがる制約を足す. 満たさなければ rule がエラーを返す
+func AddRule(rule func(Values) error) {
+	rules = append(rules, rule)
+}
+
+// All は宣言済みのパラメータを日, キーの順に返す
+func All() []*Int {
+	result := make([]*Int, 0, len(registry))
+	for _, p := range registry {
+		result = append(result, p)
+	}
+	// day10 が day2 より前に来ないよう, . の前は短い方を先にする
+	sort.Slice(result, func(i, j int) bool {
+		a, b := prefix(result[i].Key), prefix(result[j].Key)
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+func prefix(key string) string {
+	if idx := strings.IndexByte(key, '.'); 0 <= idx {
+		return key[:idx]
+	}
+	return key
+}
+
+// Values は既定値から変えたパラメータの値
+type Values map[string]int
+
+// Int は p の値を返す. 変えていなければ既定値
+func (v Values) Int(p *Int) int {
+	if value, exists := v[p.Key]; exists {
+		return value
+	}
+	return p.Default
+}
+
+// Parse は key=value の組を読んで確かめる. 知らないキー, 数でない値, 下限, 上限や制約を破る値はエラー
+func Parse(settings map[string]string) (Values, error) {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := Values{}
+	for _, key := range keys {
+		p, exists := registry[key]
+		if !exists {
+			return nil, fmt.Errorf("%w %q", ErrUnknownKey, key)
+		}
+		value, err := strconv.Atoi(settings[key])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not an integer", key, settings[key])
+		}
+		if value < p.Min {
+			return nil, fmt.Errorf("%s: %d is less than %d", key, value, p.Min)
+		}
+		if p.Max < value {
+			return nil, fmt.Errorf("%s: %d is greater than %d", key, value, p.Max)
+		}
+		values[key] = value
+	}
+
+	for _, rule := range rules {
+		if err := rule(values); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+type contextKey struct{}
+
+// With は values を載せた ctx を返す
+func With(ctx context.Context, values Values) context.Context {
+	return context.WithValue(ctx, contextKey{}, values)
+}
+
+// Get は ctx に載った p の値を返す. 載っていなければ既定値
+func (p *Int) Get(ctx context.Context) int {
+	values, _ := ctx.Value(contextKey{}).(Values)
+	return values.Int(p)
+}
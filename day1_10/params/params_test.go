@@ -0,0 +1,75 @@
+package params
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var (
+	testWidth  = NewInt("test.width", 40, 1, 1000, "width")
+	testHeight = NewInt("test.height", 6, 0, 1000, "height")
+)
+
+func init() {
+	AddRule(func(values Values) error {
+		if values.Int(testWidth) < values.Int(testHeight) {
+			return fmt.Errorf("%s must not be less than %s", testWidth.Key, testHeight.Key)
+		}
+		return nil
+	})
+}
+
+func TestDefaults(t *testing.T) {
+	ctx := context.Background()
+	if testWidth.Get(ctx) != 40 || testHeight.Get(ctx) != 6 {
+		t.Errorf("got %d x %d, want the defaults", testWidth.Get(ctx), testHeight.Get(ctx))
+	}
+
+	values, err := Parse(nil)
+	if err != nil || len(values) != 0 {
+		t.Errorf("got %v, %v", values, err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	values, err := Parse(map[string]string{"test.width": "80"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := With(context.Background(), values)
+	if testWidth.Get(ctx) != 80 || testHeight.Get(ctx) != 6 {
+		t.Errorf("got %d x %d, want 80 x 6", testWidth.Get(ctx), testHeight.Get(ctx))
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []map[string]string{
+		{"test.depth": "1"},
+		{"test.width": "wide"},
+		{"test.width": "0"},
+		{"test.width": "1001"},
+		{"test.width": "3", "test.height": "4"},
+	}
+	for _, settings := range tests {
+		if _, err := Parse(settings); err == nil {
+			t.Errorf("%v: expected an error", settings)
+		}
+	}
+
+	if _, err := Parse(map[string]string{"test.depth": "1"}); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("got %v, want ErrUnknownKey", err)
+	}
+}
+
+func TestAll(t *testing.T) {
+	keys := []string{}
+	for _, p := range All() {
+		keys = append(keys, p.Key)
+	}
+	if fmt.Sprint(keys) != "[test.height test.width]" {
+		t.Errorf("got %v", keys)
+	}
+}
@@ -0,0 +1,96 @@
+package profile
+
+/*
+profile
+
+解法の実行中の CPU プロファイル, メモリプロファイル, 実行トレースをファイルに書く.
+書いたファイルは go tool pprof, go tool trace で開く.
+*/
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// Options は書き出すファイル. 空のものは書かない
+type Options struct {
+	CPU    string
+	Memory string
+	Trace  string
+}
+
+// Start は options のプロファイルを取り始める.
+// 返す stop を呼ぶとプロファイルを止めてファイルを閉じる. メモリプロファイルは stop の時点で書く
+func Start(options Options) (stop func() error, err error) {
+	stops := []func() error{}
+	stopAll := func() error {
+		var first error
+		// 始めたのと逆の順に止める
+		for idx := len(stops) - 1; 0 <= idx; idx-- {
+			if err := stops[idx](); err != nil && first == nil {
+				first = err
+			}
+		}
+		return first
+	}
+
+	if options.CPU != "" {
+		file, err := os.Create(options.CPU)
+		if err != nil {
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			return nil, err
+		}
+		stops = append(stops, func() error {
+			pprof.StopCPUProfile()
+			return file.Close()
+		})
+	}
+
+	if options.Trace != "" {
+		file, err := os.Create(options.Trace)
+		if err != nil {
+			stopAll()
+			return nil, err
+		}
+		if err := trace.Start(file); err != nil {
+			file.Close()
+			stopAll()
+			return nil, err
+		}
+		stops = append(stops, func() error {
+			trace.Stop()
+			return file.Close()
+		})
+	}
+
+	if options.Memory != "" {
+		// 作れないパスなら実行する前に気付けるように, ファイルは先に作っておく
+		file, err := os.Create(options.Memory)
+		if err != nil {
+			stopAll()
+			return nil, err
+		}
+		stops = append(stops, func() error {
+			// 最新の状態を反映させてから書く
+			runtime.GC()
+			profile := pprof.Lookup("allocs")
+			if profile == nil {
+				file.Close()
+				return errors.New("allocs profile is not available")
+			}
+			if err := profile.WriteTo(file, 0); err != nil {
+				file.Close()
+				return err
+			}
+			return file.Close()
+		})
+	}
+
+	return stopAll, nil
+}
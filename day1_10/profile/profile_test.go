@@ -0,0 +1,71 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStart(t *testing.T) {
+	dir := t.TempDir()
+	options := Options{
+		CPU:    filepath.Join(dir, "cpu.pprof"),
+		Memory: filepath.Join(dir, "mem.pprof"),
+		Trace:  filepath.Join(dir, "trace.out"),
+	}
+
+	stop, err := Start(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// プロファイルに何か載るように少し仕事をする
+	words := []string{}
+	for idx := 0; idx < 10000; idx++ {
+		words = append(words, strings.Repeat("x", idx%32))
+	}
+	_ = words
+
+	if err := stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{options.CPU, options.Memory, options.Trace} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() == 0 {
+			t.Errorf("%s is empty", filepath.Base(path))
+		}
+	}
+}
+
+func TestStartNothing(t *testing.T) {
+	stop, err := Start(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stop(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStartBadPath(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Start(Options{
+		CPU:    filepath.Join(dir, "cpu.pprof"),
+		Memory: filepath.Join(dir, "missing", "mem.pprof"),
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	// 失敗したときは始めた CPU プロファイルも止めているので, もう一度始められる
+	stop, err := Start(Options{CPU: filepath.Join(dir, "cpu.pprof")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop()
+}
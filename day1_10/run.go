@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"Aoc2022/days"
+	"Aoc2022/params"
+	"Aoc2022/profile"
+	"Aoc2022/runner"
+	"Aoc2022/tracing"
+)
+
+func runCommand(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to run")
+	part := fs.Int("part", 0, "part to run (1 or 2); both parts when omitted")
+	inputPath := fs.String("input", "", "puzzle input file, or - for standard input (default <inputs>/dayN.txt)")
+	all := fs.Bool("all", false, "run every registered day")
+	inputDir := fs.String("inputs", defaultInputDir, "directory holding dayN.txt")
+	format := fs.String("format", "text", "output format: text or json (one record per part)")
+	workers := fs.Int("j", 1, "number of parts to run in parallel")
+	timeout := fs.Duration("timeout", 0, "time limit per part, reported as a timeout rather than an error (0 for none)")
+	traceLevel := fs.String("trace-level", "off", "write solver progress to standard error: off, info, debug or verbose")
+	reference := fs.Bool("reference", false, "run the unoptimized reference solvers instead (days with a reference only)")
+	configPath, overrides := paramFlags(fs)
+	profiling := profile.Options{}
+	fs.StringVar(&profiling.CPU, "cpuprofile", "", "write a CPU profile of the selected parts to `file`")
+	fs.StringVar(&profiling.Memory, "memprofile", "", "write an allocation profile of the selected parts to `file`")
+	fs.StringVar(&profiling.Trace, "trace", "", "write an execution trace of the selected parts to `file`")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	write, err := resultWriter(*format)
+	if err != nil {
+		return err
+	}
+
+	level, err := tracing.ParseLevel(*traceLevel)
+	if err != nil {
+		return err
+	}
+	tracing.SetLevel(level)
+
+	values, err := loadParams(*configPath, overrides)
+	if err != nil {
+		return err
+	}
+
+	jobs := []runner.Job{}
+	if *all {
+		if *day != 0 || *part != 0 || *inputPath != "" {
+			return errors.New("-all cannot be combined with -day, -part or -input")
+		}
+		for _, entry := range days.All() {
+			jobs = append(jobs, runner.Job{Entry: entry, Input: inputFile(*inputDir, entry.Day)})
+		}
+	} else {
+		if *day == 0 {
+			return errors.New("-day is required (or use -all)")
+		}
+
+		entries, err := selectEntries(*day, *part)
+		if err != nil {
+			return err
+		}
+
+		path := *inputPath
+		if path == "" {
+			path = inputFile(*inputDir, *day)
+		}
+
+		// 標準入力は 1 度しか読めないので先に読んでおく
+		var data []byte
+		if path == "-" {
+			if data, err = readInput(path); err != nil {
+				return err
+			}
+		}
+
+		for _, entry := range entries {
+			jobs = append(jobs, runner.Job{Entry: entry, Input: path, Data: data})
+		}
+	}
+
+	for idx := range jobs {
+		jobs[idx].Timeout = *timeout
+	}
+
+	if *reference {
+		for idx, job := range jobs {
+			if job.Entry.Reference == nil {
+				return fmt.Errorf("day %d has no reference solver (available: %v)", job.Entry.Day, days.ReferenceDays())
+			}
+			jobs[idx].Entry.Solver = job.Entry.Reference
+		}
+	}
+
+	stop, err := profile.Start(profiling)
+	if err != nil {
+		return err
+	}
+	// Ctrl-C で実行中のパートを打ち切る
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	ctx = params.With(ctx, values)
+	results := runner.RunAll(ctx, jobs, *workers)
+	if err := stop(); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+		if err := write(os.Stdout, result); err != nil {
+			return err
+		}
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("%d of %d part(s) failed", failed, len(jobs))
+	}
+	return nil
+}
+
+func resultWriter(format string) (func(io.Writer, runner.Result) error, error) {
+	switch format {
+	case "text":
+		return runner.WriteText, nil
+	case "json":
+		return runner.WriteJSON, nil
+	}
+	return nil, fmt.Errorf("unknown format %q (want text or json)", format)
+}
+
+func selectEntries(day int, part int) ([]days.Entry, error) {
+	if part == 0 {
+		return days.Parts(day)
+	}
+
+	entry, err := days.Lookup(day, part)
+	if err != nil {
+		return nil, err
+	}
+	return []days.Entry{entry}, nil
+}
+
+// 入力は <inputs>/dayN.txt に置く
+const defaultInputDir = "inputs"
+
+func inputFile(dir string, day int) string {
+	return filepath.Join(dir, fmt.Sprintf("day%d.txt", day))
+}
+
+// path が - なら標準入力から読む
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
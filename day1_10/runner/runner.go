@@ -0,0 +1,235 @@
+package runner
+
+/*
+runner
+
+登録された解法を入力ファイルに対して実行し, 答えと実行時間をまとめる.
+*/
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"Aoc2022/days"
+	"Aoc2022/solver"
+	"Aoc2022/tracing"
+)
+
+var tracer = tracing.New("runner")
+
+// Job は 1 パート分の実行内容.
+// Data が nil なら Input のファイルを開いて入力にする
+type Job struct {
+	Entry days.Entry
+	Input string
+	Data  []byte
+	// 0 より大きければ, この時間で打ち切ってタイムアウトとして報告する
+	Timeout time.Duration
+}
+
+// Result は 1 パート分の実行結果
+type Result struct {
+	Day      int
+	Part     int
+	Answer   solver.Answer
+	Duration time.Duration
+	Input    string
+	Err      error
+}
+
+// PanicError は解法の panic を 1 パート分の失敗として扱うためのエラー
+type PanicError struct {
+	Value interface{}
+	// panic した場所. -trace-level debug で標準エラー出力に書く
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// TimedOut は制限時間までに解き終わらなかったかどうか.
+// 答えが間違っているのとは区別して報告する
+func (r Result) TimedOut() bool {
+	return errors.Is(r.Err, context.DeadlineExceeded)
+}
+
+// Status は ok, error, timeout のいずれか
+func (r Result) Status() string {
+	switch {
+	case r.TimedOut():
+		return "timeout"
+	case r.Err != nil:
+		return "error"
+	}
+	return "ok"
+}
+
+// Run は job を実行する. 入力の読み込みは実行時間に含めない.
+// 解法が panic したらそのパートの失敗として結果を返す.
+// ctx が終わるか job.Timeout を過ぎたら, 解法が ctx を見ていなくても待たずに結果を返す
+func Run(ctx context.Context, job Job) Result {
+	result := Result{Day: job.Entry.Day, Part: job.Entry.Part, Input: job.Input}
+
+	data := job.Data
+	if data == nil {
+		content, err := os.ReadFile(job.Input)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		data = content
+	}
+
+	if 0 < job.Timeout {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	// 打ち切った後に解法が終わっても送れるように, バッファを 1 つ持たせる
+	done := make(chan Result, 1)
+	// 打ち切ったときに result を書き換えるので, 解法側には写しを渡す
+	go func(solved Result) {
+		defer func() {
+			if value := recover(); value != nil {
+				solved.Answer = solver.Answer{}
+				panicErr := &PanicError{Value: value, Stack: debug.Stack()}
+				solved.Err = panicErr
+				tracePanic(job.Entry, panicErr)
+			}
+			done <- solved
+		}()
+		solved.Answer, solved.Err = job.Entry.Solver.Solve(ctx, bytes.NewReader(data))
+	}(result)
+
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result.Err = ctx.Err()
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+func tracePanic(entry days.Entry, err *PanicError) {
+	if !tracer.Enabled(tracing.Debug) {
+		return
+	}
+	lines := []string{fmt.Sprintf("day%d part%d: %v", entry.Day, entry.Part, err)}
+	lines = append(lines, strings.Split(strings.TrimSpace(string(err.Stack)), "\n")...)
+	tracer.Lines(tracing.Debug, lines)
+}
+
+// RunAll は jobs を workers 個の goroutine で並行に実行する.
+// 結果は終わった順ではなく jobs と同じ順に並べて返す
+func RunAll(ctx context.Context, jobs []Job, workers int) []Result {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]Result, len(jobs))
+	indices := make(chan int)
+	wg := sync.WaitGroup{}
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indices {
+				results[idx] = Run(ctx, jobs[idx])
+			}
+		}()
+	}
+
+	for idx := range jobs {
+		indices <- idx
+	}
+	close(indices)
+	wg.Wait()
+
+	return results
+}
+
+type jsonResult struct {
+	Day    int           `json:"day"`
+	Part   int           `json:"part"`
+	Answer solver.Answer `json:"answer"`
+	// ナノ秒
+	Duration int64  `json:"duration"`
+	Input    string `json:"input"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+func (r Result) MarshalJSON() ([]byte, error) {
+	record := jsonResult{
+		Day:      r.Day,
+		Part:     r.Part,
+		Answer:   r.Answer,
+		Duration: r.Duration.Nanoseconds(),
+		Input:    r.Input,
+		Status:   r.Status(),
+	}
+	if r.Err != nil {
+		record.Error = r.Err.Error()
+	}
+	return json.Marshal(record)
+}
+
+// UnmarshalJSON は WriteJSON で書いたレコードを読む.
+// エラーは文字列しか残らないが, タイムアウトは TimedOut で分かるように戻す
+func (r *Result) UnmarshalJSON(data []byte) error {
+	record := jsonResult{}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return err
+	}
+
+	*r = Result{
+		Day:      record.Day,
+		Part:     record.Part,
+		Answer:   record.Answer,
+		Duration: time.Duration(record.Duration),
+		Input:    record.Input,
+	}
+	switch {
+	case record.Status == "timeout":
+		r.Err = context.DeadlineExceeded
+	case record.Error != "":
+		r.Err = errors.New(record.Error)
+	}
+	return nil
+}
+
+// WriteText は結果を人が読む形式で書き出す.
+// 絵の答え (day10 の画面など) は見出しの次の行から書く
+func WriteText(w io.Writer, result Result) error {
+	header := fmt.Sprintf("day%d part%d", result.Day, result.Part)
+
+	var err error
+	switch {
+	case result.TimedOut():
+		_, err = fmt.Fprintf(w, "%s: timeout after %s\n", header, result.Duration)
+	case result.Err != nil:
+		_, err = fmt.Fprintf(w, "%s: error: %v\n", header, result.Err)
+	case result.Answer.Kind() == solver.KindGrid:
+		_, err = fmt.Fprintf(w, "%s (%s):\n%s\n", header, result.Duration, result.Answer)
+	default:
+		_, err = fmt.Fprintf(w, "%s: %s (%s)\n", header, result.Answer, result.Duration)
+	}
+	return err
+}
+
+// WriteJSON は結果を 1 行 1 レコードの JSON で書き出す
+func WriteJSON(w io.Writer, result Result) error {
+	return json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,278 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"Aoc2022/days"
+	"Aoc2022/solver"
+	"Aoc2022/tracing"
+)
+
+func lengthSolver(ctx context.Context, r io.Reader) (solver.Answer, error) {
+	data, err := io.ReadAll(r)
+	return solver.Int(len(data)), err
+}
+
+func TestRun(t *testing.T) {
+	job := Job{
+		Entry: days.Entry{Day: 3, Part: 2, Solver: solver.Func(lengthSolver)},
+		Input: "-",
+		Data:  []byte("hello"),
+	}
+
+	result := Run(context.Background(), job)
+	if result.Err != nil {
+		t.Fatal(result.Err)
+	}
+	if result.Day != 3 || result.Part != 2 || result.Input != "-" || !result.Answer.Equal(solver.Int(5)) {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestRunMissingInput(t *testing.T) {
+	job := Job{
+		Entry: days.Entry{Day: 1, Part: 1, Solver: solver.Func(lengthSolver)},
+		Input: "testdata/missing.txt",
+	}
+
+	if result := Run(context.Background(), job); result.Err == nil {
+		t.Errorf("expected an error, got %+v", result)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	buffer := bytes.Buffer{}
+	results := []Result{
+		{Day: 5, Part: 1, Answer: solver.String("CMZ"), Duration: 1500 * time.Nanosecond, Input: "inputs/day5.txt"},
+		{Day: 7, Part: 2, Duration: 0, Input: "inputs/day7.txt", Err: errors.New("root directory not found")},
+	}
+	for _, result := range results {
+		if err := WriteJSON(&buffer, result); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per result, got %q", buffer.String())
+	}
+
+	want := []map[string]interface{}{
+		{"day": 5.0, "part": 1.0, "answer": "CMZ", "duration": 1500.0, "input": "inputs/day5.txt", "status": "ok"},
+		{"day": 7.0, "part": 2.0, "answer": nil, "duration": 0.0, "input": "inputs/day7.txt", "status": "error", "error": "root directory not found"},
+	}
+	for idx, line := range lines {
+		record := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatal(err)
+		}
+		if len(record) != len(want[idx]) {
+			t.Errorf("line %d: got %v, want %v", idx, record, want[idx])
+		}
+		for key, value := range want[idx] {
+			if record[key] != value {
+				t.Errorf("line %d: %s = %v, want %v", idx, key, record[key], value)
+			}
+		}
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	results := []Result{
+		{Day: 5, Part: 1, Answer: solver.String("CMZ"), Duration: 1500 * time.Nanosecond, Input: "inputs/day5.txt"},
+		{Day: 10, Part: 2, Answer: solver.Grid([]string{"#.", ".#"}), Input: "inputs/day10.txt"},
+		{Day: 7, Part: 2, Input: "inputs/day7.txt", Err: errors.New("root directory not found")},
+		{Day: 9, Part: 2, Duration: 5 * time.Second, Input: "inputs/day9.txt", Err: context.DeadlineExceeded},
+	}
+	for _, want := range results {
+		buffer := bytes.Buffer{}
+		if err := WriteJSON(&buffer, want); err != nil {
+			t.Fatal(err)
+		}
+
+		got := Result{}
+		if err := json.Unmarshal(buffer.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Day != want.Day || got.Part != want.Part || !got.Answer.Equal(want.Answer) || got.Duration != want.Duration || got.Input != want.Input || got.Status() != want.Status() {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+		if want.Err != nil && got.Err.Error() != want.Err.Error() {
+			t.Errorf("got error %v, want %v", got.Err, want.Err)
+		}
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	tests := []struct {
+		result Result
+		want   string
+	}{
+		{Result{Day: 1, Part: 1, Answer: solver.Int(24000), Duration: time.Millisecond}, "day1 part1: 24000 (1ms)\n"},
+		{Result{Day: 10, Part: 2, Answer: solver.Grid([]string{"#.", ".#"}), Duration: time.Millisecond}, "day10 part2 (1ms):\n#.\n.#\n"},
+		{Result{Day: 7, Part: 1, Err: errors.New("boom")}, "day7 part1: error: boom\n"},
+		{Result{Day: 9, Part: 2, Err: context.DeadlineExceeded, Duration: 5 * time.Second}, "day9 part2: timeout after 5s\n"},
+	}
+
+	for _, test := range tests {
+		buffer := bytes.Buffer{}
+		if err := WriteText(&buffer, test.result); err != nil {
+			t.Fatal(err)
+		}
+		if buffer.String() != test.want {
+			t.Errorf("got %q, want %q", buffer.String(), test.want)
+		}
+	}
+}
+
+func TestRunRecoversPanic(t *testing.T) {
+	job := Job{
+		Entry: days.Entry{Day: 7, Part: 1, Solver: solver.Func(func(ctx context.Context, r io.Reader) (solver.Answer, error) {
+			panic("invalid command detected")
+		})},
+		Data: []byte{},
+	}
+
+	result := Run(context.Background(), job)
+	panicErr, ok := result.Err.(*PanicError)
+	if !ok {
+		t.Fatalf("expected a PanicError, got %v", result.Err)
+	}
+	if panicErr.Value != "invalid command detected" || len(panicErr.Stack) == 0 {
+		t.Errorf("unexpected panic error: %v", panicErr)
+	}
+}
+
+// -trace-level debug なら panic した場所を書く
+func TestRunTracesPanic(t *testing.T) {
+	buffer := bytes.Buffer{}
+	tracing.SetOutput(&buffer)
+	tracing.SetLevel(tracing.Debug)
+	defer func() {
+		tracing.SetOutput(os.Stderr)
+		tracing.SetLevel(tracing.Off)
+	}()
+
+	job := Job{
+		Entry: days.Entry{Day: 7, Part: 1, Solver: solver.Func(func(ctx context.Context, r io.Reader) (solver.Answer, error) {
+			panic("invalid command detected")
+		})},
+		Data: []byte{},
+	}
+	Run(context.Background(), job)
+
+	output := buffer.String()
+	if !strings.Contains(output, "runner: day7 part1: panic: invalid command detected\n") || !strings.Contains(output, "runner_test.go:") {
+		t.Errorf("expected the panic and its location, got:\n%s", output)
+	}
+}
+
+// ctx を見る解法は打ち切られて ctx.Err() を返す
+func TestRunTimeout(t *testing.T) {
+	job := Job{
+		Entry: days.Entry{Day: 9, Part: 2, Solver: solver.Func(func(ctx context.Context, r io.Reader) (solver.Answer, error) {
+			<-ctx.Done()
+			return solver.Answer{}, ctx.Err()
+		})},
+		Data:    []byte{},
+		Timeout: 10 * time.Millisecond,
+	}
+
+	result := Run(context.Background(), job)
+	if !result.TimedOut() || result.Status() != "timeout" {
+		t.Errorf("expected a timeout, got %+v", result)
+	}
+}
+
+// ctx を見ない解法でも制限時間が来たら待たずに戻る
+func TestRunTimeoutUncooperative(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	job := Job{
+		Entry: days.Entry{Day: 8, Part: 1, Solver: solver.Func(func(ctx context.Context, r io.Reader) (solver.Answer, error) {
+			<-release
+			return solver.Int(1), nil
+		})},
+		Data:    []byte{},
+		Timeout: 10 * time.Millisecond,
+	}
+
+	result := Run(context.Background(), job)
+	if !result.TimedOut() {
+		t.Errorf("expected a timeout, got %+v", result)
+	}
+}
+
+// 打ち切った後に解法が終わっても, 返した結果は変わらない.
+// 解法の goroutine と結果を共有していないかは go test -race で確かめる
+func TestRunTimeoutThenFinish(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		release := make(chan struct{})
+		finished := make(chan struct{})
+		job := Job{
+			Entry: days.Entry{Day: 8, Part: 1, Solver: solver.Func(func(ctx context.Context, r io.Reader) (solver.Answer, error) {
+				defer close(finished)
+				<-release
+				return solver.Int(1), nil
+			})},
+			Data: []byte{},
+		}
+
+		// 終わった ctx で呼ぶと, 解法の goroutine が動き出す前に打ち切る
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		result := Run(ctx, job)
+		before := fmt.Sprintf("%+v", result)
+
+		close(release)
+		<-finished
+		if after := fmt.Sprintf("%+v", result); after != before || !errors.Is(result.Err, context.Canceled) || result.Answer.Kind() != solver.KindNone {
+			t.Fatalf("got %s after the solver finished, want %s (canceled, no answer)", after, before)
+		}
+	}
+}
+
+func TestRunAll(t *testing.T) {
+	jobs := []Job{}
+	for idx := 0; idx < 20; idx++ {
+		data := []byte(strings.Repeat("x", idx))
+		entry := days.Entry{Day: idx, Part: 1, Solver: solver.Func(lengthSolver)}
+		if idx == 5 {
+			entry.Solver = solver.Func(func(ctx context.Context, r io.Reader) (solver.Answer, error) {
+				panic("boom")
+			})
+		}
+		jobs = append(jobs, Job{Entry: entry, Data: data})
+	}
+
+	for _, workers := range []int{0, 1, 4, 32} {
+		results := RunAll(context.Background(), jobs, workers)
+		if len(results) != len(jobs) {
+			t.Fatalf("workers=%d: got %d results", workers, len(results))
+		}
+		for idx, result := range results {
+			if result.Day != idx {
+				t.Errorf("workers=%d: result %d is for day %d", workers, idx, result.Day)
+			}
+			if idx == 5 {
+				if result.Err == nil {
+					t.Errorf("workers=%d: expected the panic to be reported", workers)
+				}
+				continue
+			}
+			if result.Err != nil || !result.Answer.Equal(solver.Int(idx)) {
+				t.Errorf("workers=%d: unexpected result %+v", workers, result)
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package scaffold
+
+/*
+scaffold
+
+新しい日のパッケージのひな形を作る.
+解法のスタブ, 例のテスト, 空の入力ファイルを置き, days.go の一覧に登録する.
+*/
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var ErrExists = errors.New("already exists")
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+var templates = template.Must(template.ParseFS(templateFiles, "templates/*.tmpl"))
+
+// モジュール名. 日のパッケージは <module>/days/dayN に置く
+const module = "Aoc2022"
+
+// 登録先. root からの相対パス
+var registry = filepath.Join("days", "days.go")
+
+type data struct {
+	Day   int
+	Title string
+}
+
+// Create は root (go.mod のあるディレクトリ) に day 日目のひな形を作り, 作ったファイルを返す.
+// 既にパッケージか登録があるときは何も書かずに ErrExists を返す
+func Create(root string, day int, title string) ([]string, error) {
+	if day < 1 || 25 < day {
+		return nil, fmt.Errorf("day must be between 1 and 25, got %d", day)
+	}
+	if title == "" {
+		title = fmt.Sprintf("Day %d", day)
+	}
+
+	name := fmt.Sprintf("day%d", day)
+	dir := filepath.Join(root, "days", name)
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%s: %w", dir, ErrExists)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	// 失敗したときに書きかけのファイルを残さないよう, 先に全部作っておく
+	source, err := os.ReadFile(filepath.Join(root, registry))
+	if err != nil {
+		return nil, err
+	}
+	registered, err := register(source, day, title)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []struct {
+		path     string
+		template string
+	}{
+		{filepath.Join(dir, name+".go"), "day.go.tmpl"},
+		{filepath.Join(dir, name+"_test.go"), "day_test.go.tmpl"},
+		{filepath.Join(dir, "testdata", "example.txt"), ""},
+		{filepath.Join(root, "inputs", name+".txt"), ""},
+	}
+	contents := make([][]byte, len(files))
+	for idx, file := range files {
+		if file.template == "" {
+			continue
+		}
+		contents[idx], err = render(file.template, data{day, title})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	created := []string{}
+	for idx, file := range files {
+		// 入力は手で置いてあるかもしれないので上書きしない
+		if _, err := os.Stat(file.path); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(file.path), 0o755); err != nil {
+			return created, err
+		}
+		if err := os.WriteFile(file.path, contents[idx], 0o644); err != nil {
+			return created, err
+		}
+		created = append(created, file.path)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, registry), registered, 0o644); err != nil {
+		return created, err
+	}
+	return append(created, filepath.Join(root, registry)), nil
+}
+
+func render(name string, d data) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, d); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// register は days.go の import と table に day 日目を足したソースを返す
+func register(source []byte, day int, title string) ([]byte, error) {
+	text := string(source)
+	name := fmt.Sprintf("day%d", day)
+	path := strconv.Quote(module + "/days/" + name)
+	if strings.Contains(text, path) {
+		return nil, fmt.Errorf("%s in %s: %w", name, registry, ErrExists)
+	}
+
+	// import は既存の日の最後に足せば gofmt が並べ直す
+	last := strings.LastIndex(text, "\t\""+module+"/days/day")
+	if last < 0 {
+		return nil, fmt.Errorf("%s: no day imports found", registry)
+	}
+	end := last + strings.IndexByte(text[last:], '\n') + 1
+	text = text[:end] + "\t" + path + "\n" + text[end:]
+
+	// table の閉じ括弧の直前に足す
+	start := strings.Index(text, "var table = map[int]registration{\n")
+	if start < 0 {
+		return nil, fmt.Errorf("%s: registration table not found", registry)
+	}
+	closing := strings.Index(text[start:], "\n}\n")
+	if closing < 0 {
+		return nil, fmt.Errorf("%s: registration table is not closed", registry)
+	}
+	closing += start + 1
+	entry := fmt.Sprintf("\t%d: {%s, []solver.Func{%s.PartOne, %s.PartTwo}},\n", day, strconv.Quote(title), name, name)
+	text = text[:closing] + entry + text[closing:]
+
+	formatted, err := format.Source([]byte(text))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", registry, err)
+	}
+	return formatted, nil
+}
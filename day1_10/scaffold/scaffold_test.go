@@ -0,0 +1,116 @@
+package scaffold
+
+import (
+	"errors"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testdata/days.go (1, 2 日目だけ登録済み) を写した root を作る
+func newRoot(t *testing.T) string {
+	t.Helper()
+
+	source, err := os.ReadFile(filepath.Join("testdata", "days.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "days"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, registry), source, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestCreate(t *testing.T) {
+	root := newRoot(t)
+
+	created, err := Create(root, 3, "Rucksack Reorganization")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 5 {
+		t.Errorf("got %d files, want 5: %v", len(created), created)
+	}
+
+	fset := token.NewFileSet()
+	for _, name := range []string{"days/day3/day3.go", "days/day3/day3_test.go", registry} {
+		if _, err := parser.ParseFile(fset, filepath.Join(root, name), nil, parser.ParseComments); err != nil {
+			t.Errorf("%s does not parse: %v", name, err)
+		}
+	}
+
+	source, _ := os.ReadFile(filepath.Join(root, "days", "day3", "day3.go"))
+	for _, want := range []string{"package day3", "Q1.", "Q2.", "func PartOne(", "func PartTwo("} {
+		if !strings.Contains(string(source), want) {
+			t.Errorf("day3.go does not contain %q", want)
+		}
+	}
+
+	registered, _ := os.ReadFile(filepath.Join(root, registry))
+	for _, want := range []string{`"Aoc2022/days/day3"`, `3: {"Rucksack Reorganization", []solver.Func{day3.PartOne, day3.PartTwo}},`} {
+		if !strings.Contains(string(registered), want) {
+			t.Errorf("days.go does not contain %q", want)
+		}
+	}
+
+	if info, err := os.Stat(filepath.Join(root, "inputs", "day3.txt")); err != nil || info.Size() != 0 {
+		t.Errorf("expected an empty input file, got %v, %v", info, err)
+	}
+}
+
+func TestCreateExisting(t *testing.T) {
+	root := newRoot(t)
+	before, _ := os.ReadFile(filepath.Join(root, registry))
+
+	// 登録済みの日はパッケージがなくても作らない
+	if _, err := Create(root, 2, ""); !errors.Is(err, ErrExists) {
+		t.Errorf("got %v, want ErrExists", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "days", "day2")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("day2 package was created: %v", err)
+	}
+
+	if _, err := Create(root, 3, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Create(root, 3, ""); !errors.Is(err, ErrExists) {
+		t.Errorf("got %v, want ErrExists", err)
+	}
+
+	after, _ := os.ReadFile(filepath.Join(root, registry))
+	if strings.Count(string(after), "day3.PartOne") != 1 {
+		t.Errorf("day3 registered more than once:\n%s", after)
+	}
+	if string(before) == string(after) {
+		t.Error("days.go was not updated")
+	}
+}
+
+func TestCreateKeepsInput(t *testing.T) {
+	root := newRoot(t)
+	path := filepath.Join(root, "inputs", "day4.txt")
+	os.MkdirAll(filepath.Dir(path), 0o755)
+	if err := os.WriteFile(path, []byte("puzzle input\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Create(root, 4, ""); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "puzzle input\n" {
+		t.Errorf("input was overwritten: %q", data)
+	}
+}
+
+func TestCreateInvalidDay(t *testing.T) {
+	if _, err := Create(newRoot(t), 26, ""); err == nil {
+		t.Error("expected an error for day 26")
+	}
+}
@@ -0,0 +1,20 @@
+package days
+
+// テスト用の days.go. 1, 2 日目だけを登録してある
+
+import (
+	"Aoc2022/days/day1"
+	"Aoc2022/days/day2"
+	"Aoc2022/solver"
+)
+
+type registration struct {
+	title string
+	// Part1, Part2 の順
+	parts []solver.Func
+}
+
+var table = map[int]registration{
+	1: {"Calorie Counting", []solver.Func{day1.PartOne, day1.PartTwo}},
+	2: {"Rock Paper Scissors", []solver.Func{day2.PartOne, day2.PartTwo}},
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"Aoc2022/server"
+)
+
+func serveCommand(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	timeout := fs.Duration("timeout", server.DefaultOptions.Timeout, "maximum time to spend solving one request")
+	maxInput := fs.Int64("max-input", server.DefaultOptions.MaxInputSize, "maximum request body size in bytes")
+	configPath, overrides := paramFlags(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	values, err := loadParams(*configPath, overrides)
+	if err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           server.New(server.Options{Timeout: *timeout, MaxInputSize: *maxInput, Params: values}),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       time.Minute,
+	}
+
+	log.Printf("listening on http://%s", *addr)
+	return httpServer.ListenAndServe()
+}
@@ -0,0 +1,161 @@
+package server
+
+/*
+server
+
+解法を HTTP で公開する.
+
+	GET  /days                 登録済みの日とパートの一覧
+	POST /days/{n}/parts/{p}   リクエストボディを入力として解いた答え
+*/
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aoc2022/days"
+	"Aoc2022/params"
+	"Aoc2022/runner"
+)
+
+// Options はリクエストごとの制限
+type Options struct {
+	// 1 リクエストで解法を実行してよい時間
+	Timeout time.Duration
+	// 入力として受け付けるボディの最大バイト数
+	MaxInputSize int64
+	// 解法に渡すパズルの定数. nil なら既定値
+	Params params.Values
+}
+
+var DefaultOptions = Options{
+	Timeout:      10 * time.Second,
+	MaxInputSize: 1 << 20,
+}
+
+// テストで差し替えられるようにしておく
+var lookup = days.Lookup
+
+type server struct {
+	options Options
+}
+
+// New は解法を公開するハンドラを作る. 0 の項目は DefaultOptions の値を使う
+func New(options Options) http.Handler {
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultOptions.Timeout
+	}
+	if options.MaxInputSize <= 0 {
+		options.MaxInputSize = DefaultOptions.MaxInputSize
+	}
+
+	s := &server{options: options}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/days", s.handleDays)
+	mux.HandleFunc("/days/", s.handleSolve)
+	return mux
+}
+
+type dayInfo struct {
+	Day   int    `json:"day"`
+	Title string `json:"title"`
+	Parts []int  `json:"parts"`
+}
+
+func (s *server) handleDays(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	list := []dayInfo{}
+	for _, day := range days.Days() {
+		entries, _ := days.Parts(day)
+		info := dayInfo{Day: day, Title: entries[0].Title}
+		for _, entry := range entries {
+			info.Parts = append(info.Parts, entry.Part)
+		}
+		list = append(list, info)
+	}
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+// /days/{n}/parts/{p}
+func parseSolvePath(path string) (int, int, bool) {
+	elements := strings.Split(strings.Trim(path, "/"), "/")
+	if len(elements) != 4 || elements[0] != "days" || elements[2] != "parts" {
+		return 0, 0, false
+	}
+
+	day, err := strconv.Atoi(elements[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	part, err := strconv.Atoi(elements[3])
+	if err != nil {
+		return 0, 0, false
+	}
+	return day, part, true
+}
+
+func (s *server) handleSolve(w http.ResponseWriter, r *http.Request) {
+	day, part, ok := parseSolvePath(r.URL.Path)
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	entry, err := lookup(day, part)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// 上限を 1 バイト超えて読めたら大きすぎる
+	data, err := io.ReadAll(io.LimitReader(r.Body, s.options.MaxInputSize+1))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if s.options.MaxInputSize < int64(len(data)) {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("input exceeds %d bytes", s.options.MaxInputSize))
+		return
+	}
+
+	// 解法が終わらなくても, 制限時間が来たら runner.Run は待たずに戻る
+	ctx := params.With(r.Context(), s.options.Params)
+	result := runner.Run(ctx, runner.Job{Entry: entry, Input: "request", Data: data, Timeout: s.options.Timeout})
+	switch {
+	case r.Context().Err() != nil:
+		// クライアントが切断した
+	case result.TimedOut():
+		writeJSON(w, http.StatusServiceUnavailable, result)
+	case result.Err != nil:
+		writeJSON(w, http.StatusUnprocessableEntity, result)
+	default:
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
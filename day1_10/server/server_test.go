@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"Aoc2022/days"
+	"Aoc2022/params"
+	"Aoc2022/solver"
+)
+
+func request(t *testing.T, handler http.Handler, method string, path string, body string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+	response := recorder.Result()
+
+	decoded := map[string]interface{}{}
+	if strings.HasPrefix(path, "/days/") || response.StatusCode != http.StatusOK {
+		if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+	}
+	return response, decoded
+}
+
+func TestListDays(t *testing.T) {
+	server := httptest.NewServer(New(Options{}))
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/days")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d", response.StatusCode)
+	}
+
+	list := []dayInfo{}
+	if err := json.NewDecoder(response.Body).Decode(&list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != len(days.Days()) {
+		t.Fatalf("got %d days, want %d", len(list), len(days.Days()))
+	}
+	if list[0].Day != 1 || list[0].Title != "Calorie Counting" || len(list[0].Parts) != 2 {
+		t.Errorf("unexpected first day: %+v", list[0])
+	}
+}
+
+func TestSolve(t *testing.T) {
+	input, err := os.ReadFile("../days/day1/testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, body := request(t, New(Options{}), http.MethodPost, "/days/1/parts/2", string(input))
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d: %v", response.StatusCode, body)
+	}
+	if body["answer"] != 45000.0 || body["day"] != 1.0 || body["part"] != 2.0 {
+		t.Errorf("unexpected body: %v", body)
+	}
+}
+
+// Options.Params の定数で解く. day1 Part2 を上位 1 人にすると Part1 と同じ答え
+func TestSolveParams(t *testing.T) {
+	input, err := os.ReadFile("../days/day1/testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := New(Options{Params: params.Values{"day1.top": 1}})
+	response, body := request(t, handler, http.MethodPost, "/days/1/parts/2", string(input))
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("got status %d: %v", response.StatusCode, body)
+	}
+	if body["answer"] != 24000.0 {
+		t.Errorf("unexpected body: %v", body)
+	}
+}
+
+func TestSolveErrors(t *testing.T) {
+	handler := New(Options{MaxInputSize: 16})
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{http.MethodPost, "/days/26/parts/1", "", http.StatusNotFound},
+		{http.MethodPost, "/days/1/parts/3", "", http.StatusNotFound},
+		{http.MethodPost, "/days/x/parts/1", "", http.StatusNotFound},
+		{http.MethodPost, "/days/1", "", http.StatusNotFound},
+		{http.MethodGet, "/days/1/parts/1", "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/days", "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/days/1/parts/1", strings.Repeat("1\n", 9), http.StatusRequestEntityTooLarge},
+		{http.MethodPost, "/days/7/parts/1", "$ rm -rf /\n", http.StatusUnprocessableEntity},
+	}
+
+	for _, test := range tests {
+		response, body := request(t, handler, test.method, test.path, test.body)
+		if response.StatusCode != test.status {
+			t.Errorf("%s %s: got status %d, want %d", test.method, test.path, response.StatusCode, test.status)
+		}
+		if body["error"] == nil || body["error"] == "" {
+			t.Errorf("%s %s: expected an error message, got %v", test.method, test.path, body)
+		}
+	}
+}
+
+func TestSolveTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	original := lookup
+	defer func() { lookup = original }()
+	lookup = func(day int, part int) (days.Entry, error) {
+		return days.Entry{Day: day, Part: part, Solver: solver.Func(func(ctx context.Context, r io.Reader) (solver.Answer, error) {
+			<-release
+			return solver.Int(0), nil
+		})}, nil
+	}
+
+	response, body := request(t, New(Options{Timeout: 10 * time.Millisecond}), http.MethodPost, "/days/1/parts/1", "")
+	if response.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("got status %d, want %d: %v", response.StatusCode, http.StatusServiceUnavailable, body)
+	}
+	if body["status"] != "timeout" {
+		t.Errorf("got status %v, want timeout", body["status"])
+	}
+}
@@ -0,0 +1,192 @@
+package site
+
+/*
+site
+
+Advent of Code のサイトとのやりとり.
+入力は session cookie を付けて取得し, inputs/ にキャッシュする.
+答えを送って判定 (正解, 大きすぎる, 小さすぎる, 待て) を読む.
+サイトに負担をかけないよう, リクエストの間隔を空ける.
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBaseURL = "https://adventofcode.com"
+	Year           = 2022
+	// 問い合わせ先が分かるようにする
+	UserAgent = "github.com/66a-11a4S/AoC2022 aoc-cli (Go net/http)"
+)
+
+var (
+	ErrNoSession    = errors.New("no session token")
+	ErrSession      = errors.New("session token rejected")
+	ErrNotAvailable = errors.New("puzzle not available")
+)
+
+// Options は Client の設定. 0 の項目は既定値を使う
+type Options struct {
+	BaseURL string
+	Session string
+	// リクエストの最小間隔
+	Interval   time.Duration
+	HTTPClient *http.Client
+}
+
+var DefaultOptions = Options{
+	BaseURL:    DefaultBaseURL,
+	Interval:   5 * time.Second,
+	HTTPClient: &http.Client{Timeout: 30 * time.Second},
+}
+
+// 入力として受け取る最大バイト数. 本物の入力は数十 KB
+const maxInputSize = 16 << 20
+
+// Client はサイトへのリクエストを送る. 複数の goroutine から使ってよい
+type Client struct {
+	options Options
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+func New(options Options) *Client {
+	if options.BaseURL == "" {
+		options.BaseURL = DefaultOptions.BaseURL
+	}
+	options.BaseURL = strings.TrimRight(options.BaseURL, "/")
+	if options.Interval <= 0 {
+		options.Interval = DefaultOptions.Interval
+	}
+	if options.HTTPClient == nil {
+		options.HTTPClient = DefaultOptions.HTTPClient
+	}
+	return &Client{options: options}
+}
+
+// wait は前のリクエストから Interval 経つまで待つ
+func (c *Client) wait(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.last.IsZero() {
+		if delay := c.options.Interval - time.Since(c.last); delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+	c.last = time.Now()
+	return nil
+}
+
+// do は session cookie と User-Agent を付けて request を送る
+func (c *Client) do(ctx context.Context, request *http.Request) (*http.Response, error) {
+	if c.options.Session == "" {
+		return nil, ErrNoSession
+	}
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("User-Agent", UserAgent)
+	request.AddCookie(&http.Cookie{Name: "session", Value: c.options.Session})
+	return c.options.HTTPClient.Do(request.WithContext(ctx))
+}
+
+func (c *Client) dayURL(day int) string {
+	return fmt.Sprintf("%s/%d/day/%d", c.options.BaseURL, Year, day)
+}
+
+// checkStatus は 200 以外の応答をエラーにする
+func checkStatus(response *http.Response, day int) error {
+	switch response.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("day %d: %w", day, ErrNotAvailable)
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("day %d: %w (%s)", day, ErrSession, response.Status)
+	}
+	return fmt.Errorf("day %d: unexpected response %s", day, response.Status)
+}
+
+// Input は day 日目の入力をダウンロードする
+func (c *Client) Input(ctx context.Context, day int) ([]byte, error) {
+	request, err := http.NewRequest(http.MethodGet, c.dayURL(day)+"/input", nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.do(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if err := checkStatus(response, day); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxInputSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxInputSize {
+		return nil, fmt.Errorf("day %d: input larger than %d bytes", day, maxInputSize)
+	}
+	return data, nil
+}
+
+// Fetch は dir/dayN.txt に入力を置いてそのパスを返す.
+// 空でないファイルが既にあればダウンロードせず, downloaded は false になる
+func (c *Client) Fetch(ctx context.Context, dir string, day int) (path string, downloaded bool, err error) {
+	path = filepath.Join(dir, fmt.Sprintf("day%d.txt", day))
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+		return path, false, nil
+	}
+
+	data, err := c.Input(ctx, day)
+	if err != nil {
+		return "", false, err
+	}
+	if err := writeFile(path, data); err != nil {
+		return "", false, err
+	}
+	return path, true, nil
+}
+
+// writeFile は途中で失敗しても書きかけのファイルが残らないよう, 一時ファイルから rename する
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
@@ -0,0 +1,145 @@
+package site
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeSite は session が secret のときだけ入力を返す
+func fakeSite(t *testing.T, requests *int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if r.Header.Get("User-Agent") != UserAgent {
+			t.Errorf("got User-Agent %q", r.Header.Get("User-Agent"))
+		}
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/2022/day/6/input":
+			w.Write([]byte("mjqjpqmgbljsphdztnvjfqwrcgsmlb\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetch(t *testing.T) {
+	var requests int32
+	server := fakeSite(t, &requests)
+	client := New(Options{BaseURL: server.URL + "/", Session: "secret", Interval: time.Millisecond})
+	dir := filepath.Join(t.TempDir(), "inputs")
+
+	path, downloaded, err := client.Fetch(context.Background(), dir, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !downloaded || path != filepath.Join(dir, "day6.txt") {
+		t.Errorf("got %s, %v", path, downloaded)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "mjqjpqmgbljsphdztnvjfqwrcgsmlb\n" {
+		t.Errorf("got %q", data)
+	}
+
+	// 2 回目はキャッシュを使う
+	if _, downloaded, err := client.Fetch(context.Background(), dir, 6); err != nil || downloaded {
+		t.Errorf("got %v, %v, want the cached input", downloaded, err)
+	}
+	if atomic.LoadInt32(&requests) != 1 {
+		t.Errorf("got %d requests, want 1", requests)
+	}
+}
+
+func TestFetchKeepsCacheWithoutSession(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "day6.txt"), []byte("cached\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// キャッシュがあればサイトにも session にも触らない
+	client := New(Options{BaseURL: "http://127.0.0.1:0"})
+	if _, downloaded, err := client.Fetch(context.Background(), dir, 6); err != nil || downloaded {
+		t.Errorf("got %v, %v, want the cached input", downloaded, err)
+	}
+}
+
+func TestFetchReplacesEmptyFile(t *testing.T) {
+	var requests int32
+	server := fakeSite(t, &requests)
+	dir := t.TempDir()
+	// aoc new が置く空の入力
+	if err := os.WriteFile(filepath.Join(dir, "day6.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := New(Options{BaseURL: server.URL, Session: "secret"})
+	if _, downloaded, err := client.Fetch(context.Background(), dir, 6); err != nil || !downloaded {
+		t.Errorf("got %v, %v, want a download", downloaded, err)
+	}
+}
+
+func TestFetchErrors(t *testing.T) {
+	var requests int32
+	server := fakeSite(t, &requests)
+
+	tests := []struct {
+		session string
+		day     int
+		want    error
+	}{
+		{"", 6, ErrNoSession},
+		{"wrong", 6, ErrSession},
+		{"secret", 25, ErrNotAvailable},
+	}
+	for _, test := range tests {
+		dir := t.TempDir()
+		client := New(Options{BaseURL: server.URL, Session: test.session, Interval: time.Millisecond})
+		if _, _, err := client.Fetch(context.Background(), dir, test.day); !errors.Is(err, test.want) {
+			t.Errorf("session %q day %d: got %v, want %v", test.session, test.day, err, test.want)
+		}
+		// 失敗したらファイルを残さない
+		if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+			t.Errorf("session %q day %d: left %v", test.session, test.day, entries)
+		}
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	var requests int32
+	server := fakeSite(t, &requests)
+	interval := 100 * time.Millisecond
+	client := New(Options{BaseURL: server.URL, Session: "secret", Interval: interval})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Input(context.Background(), 6); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 2*interval {
+		t.Errorf("3 requests took %s, want at least %s", elapsed, 2*interval)
+	}
+
+	// 待っている間に ctx が終わったら送らない
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Input(ctx, 6); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+	if atomic.LoadInt32(&requests) != 3 {
+		t.Errorf("got %d requests, want 3", requests)
+	}
+}
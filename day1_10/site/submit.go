@@ -0,0 +1,122 @@
+package site
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outcome は答えを送ったときのサイトの判定
+type Outcome string
+
+const (
+	Correct Outcome = "correct"
+	TooHigh Outcome = "too high"
+	TooLow  Outcome = "too low"
+	// 大小のヒントがない不正解
+	Wrong Outcome = "wrong"
+	// 前に送ってから間がなく, 判定されなかった
+	TooRecent Outcome = "too recent"
+	// そのパートは今は答えられない. 解き終わったパートにも, 前のパートを解く前の Part2 にも同じ文面が返る
+	WrongLevel Outcome = "wrong level"
+)
+
+// Verdict は答えを送った結果
+type Verdict struct {
+	Outcome Outcome
+	// 次に送れるようになるまでの時間. 分からなければ 0
+	Wait time.Duration
+	// サイトの文面からタグを取り除いたもの
+	Message string
+}
+
+// 応答の文面を読むための目印. 上から順に確かめる
+var outcomes = []struct {
+	phrase  string
+	outcome Outcome
+}{
+	{"That's the right answer", Correct},
+	{"your answer is too high", TooHigh},
+	{"your answer is too low", TooLow},
+	{"That's not the right answer", Wrong},
+	{"You gave an answer too recently", TooRecent},
+	{"You don't seem to be solving the right level", WrongLevel},
+}
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+	spacePattern   = regexp.MustCompile(`\s+`)
+	// 不正解のとき: "Please wait one minute before trying again", "please wait 5 minutes ..."
+	minutesPattern = regexp.MustCompile(`wait (one|\d+) minutes?`)
+	// 間がなかったとき: "You have 1m 30s left to wait", "You have 34s left to wait"
+	leftPattern = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+)
+
+// ParseVerdict は答えを送ったときの応答 (HTML) を読む
+func ParseVerdict(page string) (Verdict, error) {
+	text := page
+	if match := articlePattern.FindStringSubmatch(page); match != nil {
+		text = match[1]
+	}
+	text = html.UnescapeString(tagPattern.ReplaceAllString(text, ""))
+	text = strings.TrimSpace(spacePattern.ReplaceAllString(text, " "))
+
+	verdict := Verdict{Message: text}
+	for _, candidate := range outcomes {
+		if strings.Contains(text, candidate.phrase) {
+			verdict.Outcome = candidate.outcome
+			break
+		}
+	}
+	if verdict.Outcome == "" {
+		return Verdict{}, fmt.Errorf("unrecognized response: %q", text)
+	}
+
+	if match := minutesPattern.FindStringSubmatch(text); match != nil {
+		minutes := 1
+		if match[1] != "one" {
+			minutes, _ = strconv.Atoi(match[1])
+		}
+		verdict.Wait = time.Duration(minutes) * time.Minute
+	}
+	if match := leftPattern.FindStringSubmatch(text); match != nil {
+		minutes, _ := strconv.Atoi(match[1])
+		seconds, _ := strconv.Atoi(match[2])
+		verdict.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	}
+	return verdict, nil
+}
+
+// Submit は day 日目の part の答えを送って判定を返す
+func (c *Client) Submit(ctx context.Context, day int, part int, answer string) (Verdict, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	request, err := http.NewRequest(http.MethodPost, c.dayURL(day)+"/answer", strings.NewReader(form.Encode()))
+	if err != nil {
+		return Verdict{}, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := c.do(ctx, request)
+	if err != nil {
+		return Verdict{}, err
+	}
+	defer response.Body.Close()
+
+	if err := checkStatus(response, day); err != nil {
+		return Verdict{}, err
+	}
+
+	page, err := io.ReadAll(io.LimitReader(response.Body, maxInputSize))
+	if err != nil {
+		return Verdict{}, err
+	}
+	return ParseVerdict(string(page))
+}
@@ -0,0 +1,79 @@
+package site
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// サイトの応答を縮めたもの
+const (
+	correctPage    = `<main><article><p>That's the right answer!  You are <span class="day-success">one gold star</span> closer to collecting enough star fruit.</p></article></main>`
+	tooHighPage    = `<main><article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data.  Please wait one minute before trying again. [<a href="/2022/day/1">Return to Day 1</a>]</p></article></main>`
+	tooLowPage     = `<main><article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.</p></article></main>`
+	wrongPage      = `<main><article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data; there are also some general tips on the <a href="/2022/about">about page</a>.  Please wait one minute before trying again.</p></article></main>`
+	tooRecentPage  = `<main><article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 30s left to wait. [<a href="/2022/day/1">Return to Day 1</a>]</p></article></main>`
+	wrongLevelPage = `<main><article><p>You don't seem to be solving the right level.  Did you already complete it? [<a href="/2022/day/1">Return to Day 1</a>]</p></article></main>`
+)
+
+func TestParseVerdict(t *testing.T) {
+	tests := []struct {
+		page    string
+		outcome Outcome
+		wait    time.Duration
+	}{
+		{correctPage, Correct, 0},
+		{tooHighPage, TooHigh, time.Minute},
+		{tooLowPage, TooLow, 5 * time.Minute},
+		{wrongPage, Wrong, time.Minute},
+		{tooRecentPage, TooRecent, 90 * time.Second},
+		{wrongLevelPage, WrongLevel, 0},
+	}
+	for _, test := range tests {
+		verdict, err := ParseVerdict(test.page)
+		if err != nil {
+			t.Errorf("%s: %v", test.outcome, err)
+			continue
+		}
+		if verdict.Outcome != test.outcome || verdict.Wait != test.wait {
+			t.Errorf("got (%s, %s), want (%s, %s): %s", verdict.Outcome, verdict.Wait, test.outcome, test.wait, verdict.Message)
+		}
+	}
+
+	if _, err := ParseVerdict("<html>maintenance</html>"); err == nil {
+		t.Error("expected an error for an unknown page")
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2022/day/1/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.FormValue("level") != "1" {
+			t.Errorf("got level %q", r.FormValue("level"))
+		}
+		if r.FormValue("answer") == "24000" {
+			w.Write([]byte(correctPage))
+		} else {
+			w.Write([]byte(tooHighPage))
+		}
+	}))
+	defer server.Close()
+
+	client := New(Options{BaseURL: server.URL, Session: "secret", Interval: time.Millisecond})
+	verdict, err := client.Submit(context.Background(), 1, 1, "99999")
+	if err != nil || verdict.Outcome != TooHigh {
+		t.Errorf("got %v, %v, want too high", verdict, err)
+	}
+	verdict, err = client.Submit(context.Background(), 1, 1, "24000")
+	if err != nil || verdict.Outcome != Correct {
+		t.Errorf("got %v, %v, want correct", verdict, err)
+	}
+	if _, err := client.Submit(context.Background(), 30, 1, "1"); err == nil {
+		t.Error("expected an error for a missing day")
+	}
+}
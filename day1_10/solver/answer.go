@@ -0,0 +1,137 @@
+package solver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind は Answer が持つ値の種類
+type Kind int
+
+const (
+	KindNone Kind = iota
+	KindInt
+	KindString
+	KindGrid
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindInt:
+		return "int"
+	case KindString:
+		return "string"
+	case KindGrid:
+		return "grid"
+	}
+	return "none"
+}
+
+// Answer は解法が出した答え.
+// 整数, 文字列, ピクセルの絵 (day10 の CRT など) のいずれかを持つ.
+// 同じ答えどうしは == で比較できる.
+type Answer struct {
+	kind  Kind
+	value int
+	// 文字列, または絵の各行を "\n" でつないだもの
+	text string
+}
+
+// Int は整数の答えを作る
+func Int(value int) Answer {
+	return Answer{kind: KindInt, value: value}
+}
+
+// String は文字列の答えを作る
+func String(text string) Answer {
+	return Answer{kind: KindString, text: text}
+}
+
+// Grid は各行を上から並べた絵の答えを作る
+func Grid(rows []string) Answer {
+	return Answer{kind: KindGrid, text: strings.Join(rows, "\n")}
+}
+
+func (a Answer) Kind() Kind {
+	return a.kind
+}
+
+// Int は整数の答えの値を返す. 整数でなければ false
+func (a Answer) Int() (int, bool) {
+	return a.value, a.kind == KindInt
+}
+
+// Rows は絵の答えの各行を返す. 絵でなければ nil
+func (a Answer) Rows() []string {
+	if a.kind != KindGrid {
+		return nil
+	}
+	return strings.Split(a.text, "\n")
+}
+
+// String は答えを表示用に整形する. 絵は行ごとに改行でつなぐ
+func (a Answer) String() string {
+	switch a.kind {
+	case KindInt:
+		return strconv.Itoa(a.value)
+	case KindString, KindGrid:
+		return a.text
+	}
+	return ""
+}
+
+func (a Answer) Equal(b Answer) bool {
+	return a == b
+}
+
+// MarshalJSON は整数を数値, 文字列を文字列, 絵を行の配列として書き出す
+func (a Answer) MarshalJSON() ([]byte, error) {
+	switch a.kind {
+	case KindInt:
+		return json.Marshal(a.value)
+	case KindString:
+		return json.Marshal(a.text)
+	case KindGrid:
+		return json.Marshal(a.Rows())
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON は MarshalJSON の形式から答えを読む
+func (a *Answer) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+
+	switch value := value.(type) {
+	case nil:
+		*a = Answer{}
+	case json.Number:
+		n, err := strconv.Atoi(value.String())
+		if err != nil {
+			return fmt.Errorf("answer %s is not an integer", value)
+		}
+		*a = Int(n)
+	case string:
+		*a = String(value)
+	case []interface{}:
+		rows := make([]string, len(value))
+		for idx, row := range value {
+			text, ok := row.(string)
+			if !ok {
+				return fmt.Errorf("grid row %d is not a string", idx)
+			}
+			rows[idx] = text
+		}
+		*a = Grid(rows)
+	default:
+		return fmt.Errorf("unsupported answer %s", data)
+	}
+	return nil
+}
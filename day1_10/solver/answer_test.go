@@ -0,0 +1,89 @@
+package solver
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAnswerString(t *testing.T) {
+	tests := []struct {
+		answer Answer
+		want   string
+	}{
+		{Int(24000), "24000"},
+		{Int(-3), "-3"},
+		{String("CMZ"), "CMZ"},
+		{Grid([]string{"#.", ".#"}), "#.\n.#"},
+		{Answer{}, ""},
+	}
+
+	for _, test := range tests {
+		if got := test.answer.String(); got != test.want {
+			t.Errorf("%#v: got %q, want %q", test.answer, got, test.want)
+		}
+	}
+}
+
+func TestAnswerEqual(t *testing.T) {
+	if !Int(7).Equal(Int(7)) {
+		t.Error("Int(7) should equal Int(7)")
+	}
+	if Int(7).Equal(String("7")) {
+		t.Error("an int answer should not equal a string answer with the same text")
+	}
+	if String("#.\n.#").Equal(Grid([]string{"#.", ".#"})) {
+		t.Error("a string answer should not equal a grid answer with the same text")
+	}
+	if !Grid([]string{"#.", ".#"}).Equal(Grid([]string{"#.", ".#"})) {
+		t.Error("grids with the same rows should be equal")
+	}
+}
+
+func TestAnswerRows(t *testing.T) {
+	rows := Grid([]string{"##", ".."}).Rows()
+	if len(rows) != 2 || rows[0] != "##" || rows[1] != ".." {
+		t.Errorf("got %q", rows)
+	}
+	if Int(1).Rows() != nil {
+		t.Error("non-grid answers should have no rows")
+	}
+}
+
+func TestAnswerJSON(t *testing.T) {
+	tests := []struct {
+		answer Answer
+		json   string
+	}{
+		{Int(24000), `24000`},
+		{String("CMZ"), `"CMZ"`},
+		{Grid([]string{"#.", ".#"}), `["#.",".#"]`},
+		{Answer{}, `null`},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.answer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != test.json {
+			t.Errorf("marshal %s: got %s, want %s", test.answer, data, test.json)
+		}
+
+		var decoded Answer
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Equal(test.answer) {
+			t.Errorf("unmarshal %s: got %#v, want %#v", data, decoded, test.answer)
+		}
+	}
+}
+
+func TestAnswerUnmarshalJSONRejectsInvalid(t *testing.T) {
+	for _, data := range []string{`1.5`, `true`, `["#", 1]`, `{}`} {
+		var answer Answer
+		if err := json.Unmarshal([]byte(data), &answer); err == nil {
+			t.Errorf("%s: expected an error, got %#v", data, answer)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package solver
+
+/*
+solver
+
+各日の解法が満たす共通のインターフェース.
+入力は io.Reader から受け取り, 答えとエラーを値として返す.
+長いループを持つ解法は ctx が終わったら途中でやめて ctx.Err() を返す.
+*/
+
+import (
+	"context"
+	"io"
+)
+
+// Solver は 1 パート分の解法
+type Solver interface {
+	Solve(ctx context.Context, r io.Reader) (Answer, error)
+}
+
+// Func は関数を Solver として扱うためのアダプタ
+type Func func(ctx context.Context, r io.Reader) (Answer, error)
+
+func (f Func) Solve(ctx context.Context, r io.Reader) (Answer, error) {
+	return f(ctx, r)
+}
+
+// ctx.Err はロックを取るので, 長いループでは何回かに 1 回だけ確かめる
+const pollInterval = 1024
+
+// Poll は i が pollInterval の倍数のときだけ ctx を確かめる.
+// ループの中で毎回呼んでも重くならない
+func Poll(ctx context.Context, i int) error {
+	if i%pollInterval != 0 {
+		return nil
+	}
+	return ctx.Err()
+}
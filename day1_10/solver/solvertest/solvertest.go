@@ -0,0 +1,69 @@
+package solvertest
+
+/*
+solvertest
+
+解法のテストで使う共通処理
+*/
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	inputpkg "Aoc2022/input"
+	"Aoc2022/params"
+	"Aoc2022/solver"
+)
+
+// WithParams は values を ctx に載せて f を呼ぶ解法を返す
+func WithParams(f solver.Func, values params.Values) solver.Func {
+	return func(ctx context.Context, r io.Reader) (solver.Answer, error) {
+		return f(params.With(ctx, values), r)
+	}
+}
+
+// ReadFile は testdata などに置いた入力を読む
+func ReadFile(tb testing.TB, path string) string {
+	tb.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return string(data)
+}
+
+// Check は input を解いた答えが want と一致するか確かめる
+func Check(tb testing.TB, f solver.Func, input string, want solver.Answer) {
+	tb.Helper()
+
+	got, err := f.Solve(context.Background(), strings.NewReader(input))
+	if err != nil {
+		tb.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Equal(want) {
+		tb.Errorf("got %s (%s), want %s (%s)", got, got.Kind(), want, want.Kind())
+	}
+}
+
+// CheckError は input を解くと, line 行目を指す入力エラーになるか確かめる
+func CheckError(tb testing.TB, f solver.Func, input string, line int) {
+	tb.Helper()
+
+	got, err := f.Solve(context.Background(), strings.NewReader(input))
+	if err == nil {
+		tb.Fatalf("expected an error, got %s", got)
+	}
+
+	var inputErr *inputpkg.Error
+	if !errors.As(err, &inputErr) {
+		tb.Fatalf("expected an input error, got %v", err)
+	}
+	if inputErr.Line != line {
+		tb.Errorf("got error at line %d, want %d: %v", inputErr.Line, line, err)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"Aoc2022/attempts"
+	"Aoc2022/config"
+	"Aoc2022/days"
+	"Aoc2022/runner"
+	"Aoc2022/site"
+	"Aoc2022/solver"
+)
+
+func submitCommand(args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to submit")
+	part := fs.Int("part", 0, "part to submit (1 or 2)")
+	answer := fs.String("answer", "", "answer to submit instead of running the solver (needed for grid answers)")
+	inputPath := fs.String("input", "", "puzzle input file (default <inputs>/dayN.txt)")
+	inputDir := fs.String("inputs", defaultInputDir, "directory holding dayN.txt")
+	timeout := fs.Duration("timeout", 0, "time limit for the solver (0 for none)")
+	logPath := fs.String("log", "submissions.json", "`file` recording every submitted answer and its outcome")
+	configPath := fs.String("config", config.DefaultPath(), "config `file` holding the session token")
+	baseURL := fs.String("base-url", "", "site to submit to (default from the config file, or "+site.DefaultBaseURL+")")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	if *day == 0 || *part == 0 {
+		return errors.New("-day and -part are required")
+	}
+
+	entry, err := days.Lookup(*day, *part)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	value := *answer
+	if value == "" {
+		path := *inputPath
+		if path == "" {
+			path = inputFile(*inputDir, *day)
+		}
+		if value, err = solve(ctx, runner.Job{Entry: entry, Input: path, Timeout: *timeout}); err != nil {
+			return err
+		}
+	}
+
+	log, err := attempts.Load(*logPath)
+	if err != nil {
+		return err
+	}
+	if err := log.Check(*day, *part, value, time.Now()); err != nil {
+		return err
+	}
+
+	client, err := newSiteClient(*configPath, *baseURL)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("day%d part%d: submitting %s\n", *day, *part, value)
+	verdict, err := client.Submit(ctx, *day, *part, value)
+	if errors.Is(err, site.ErrNoSession) {
+		return fmt.Errorf("%w: set %s or \"session\" in %s", err, config.SessionEnv, *configPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Record(*day, *part, value, verdict, time.Now())
+	if err := log.Save(*logPath); err != nil {
+		return err
+	}
+
+	fmt.Println(verdict.Message)
+	if verdict.Outcome != site.Correct {
+		return fmt.Errorf("day %d part %d: %s", *day, *part, verdict.Outcome)
+	}
+	return nil
+}
+
+// solve は解法を実行して, 送れる形の答えを返す
+func solve(ctx context.Context, job runner.Job) (string, error) {
+	result := runner.Run(ctx, job)
+	if result.Err != nil {
+		return "", fmt.Errorf("day %d part %d: %w", job.Entry.Day, job.Entry.Part, result.Err)
+	}
+	// 画面の文字は目で読むしかない
+	if result.Answer.Kind() == solver.KindGrid {
+		return "", fmt.Errorf("day %d part %d: the answer is a grid; read it and pass the letters with -answer:\n%s", job.Entry.Day, job.Entry.Part, result.Answer)
+	}
+	return result.Answer.String(), nil
+}
@@ -0,0 +1,118 @@
+package tracing
+
+/*
+tracing
+
+解法の途中経過を標準エラー出力に書く.
+レベルを上げるほど細かい経過を書く. 既定は Off で何も書かない.
+
+* Info: パートごとの要約
+* Debug: 入力の 1 行ごと, 答えが更新されたときなど
+* Verbose: シミュレーションの 1 歩ごとの盤面など
+*/
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type Level int32
+
+const (
+	Off Level = iota
+	Info
+	Debug
+	Verbose
+)
+
+var levelNames = []string{"off", "info", "debug", "verbose"}
+
+func (l Level) String() string {
+	if l < Off || Verbose < l {
+		return "Level(" + strconv.Itoa(int(l)) + ")"
+	}
+	return levelNames[l]
+}
+
+// ParseLevel はレベルの名前か番号を読む
+func ParseLevel(text string) (Level, error) {
+	for idx, name := range levelNames {
+		if strings.EqualFold(text, name) || text == strconv.Itoa(idx) {
+			return Level(idx), nil
+		}
+	}
+	return Off, fmt.Errorf("unknown trace level %q (want %s)", text, strings.Join(levelNames, ", "))
+}
+
+var (
+	// -j で並行に実行しても読めるように atomic で持つ
+	current int32
+
+	mu     sync.Mutex
+	output io.Writer = os.Stderr
+)
+
+func SetLevel(level Level) {
+	atomic.StoreInt32(&current, int32(level))
+}
+
+func CurrentLevel() Level {
+	return Level(atomic.LoadInt32(&current))
+}
+
+// SetOutput は書き込み先を変える. 既定は標準エラー出力
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	output = w
+}
+
+// Tracer は名前を付けて経過を書く. 各日のパッケージで 1 つずつ作る
+type Tracer struct {
+	name string
+}
+
+func New(name string) Tracer {
+	return Tracer{name: name}
+}
+
+// Enabled は level の経過を書くかどうか.
+// ループの中で引数を作るのが重いときは, 先にこれで確かめる
+func (t Tracer) Enabled(level Level) bool {
+	return level != Off && level <= CurrentLevel()
+}
+
+// Printf は 1 行の経過を書く. 末尾の改行はなくてよい
+func (t Tracer) Printf(level Level, format string, args ...interface{}) {
+	if !t.Enabled(level) {
+		return
+	}
+	t.write([]string{fmt.Sprintf(format, args...)})
+}
+
+// Lines は盤面など複数行の経過をまとめて書く
+func (t Tracer) Lines(level Level, lines []string) {
+	if !t.Enabled(level) {
+		return
+	}
+	t.write(lines)
+}
+
+func (t Tracer) write(lines []string) {
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(t.name)
+		b.WriteString(": ")
+		b.WriteString(strings.TrimSuffix(line, "\n"))
+		b.WriteString("\n")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	io.WriteString(output, b.String())
+}
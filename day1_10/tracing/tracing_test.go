@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func capture(t *testing.T, level Level) *bytes.Buffer {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	SetLevel(level)
+	t.Cleanup(func() {
+		SetOutput(os.Stderr)
+		SetLevel(Off)
+	})
+	return &buf
+}
+
+func TestPrintf(t *testing.T) {
+	buf := capture(t, Debug)
+	tracer := New("day8")
+
+	tracer.Printf(Info, "result %d", 21)
+	tracer.Printf(Debug, "updated at: (%d, %d)\n", 2, 3)
+	tracer.Printf(Verbose, "not written")
+	tracer.Lines(Debug, []string{"30373", "25512"})
+
+	want := "day8: result 21\nday8: updated at: (2, 3)\nday8: 30373\nday8: 25512\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestOff(t *testing.T) {
+	buf := capture(t, Off)
+	tracer := New("day1")
+
+	tracer.Printf(Info, "hidden")
+	if tracer.Enabled(Info) || tracer.Enabled(Off) || buf.Len() != 0 {
+		t.Errorf("expected nothing to be written, got %q", buf.String())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		text string
+		want Level
+	}{
+		{"off", Off},
+		{"info", Info},
+		{"DEBUG", Debug},
+		{"3", Verbose},
+	}
+	for _, test := range tests {
+		got, err := ParseLevel(test.text)
+		if err != nil || got != test.want {
+			t.Errorf("ParseLevel(%q) = %v, %v, want %v", test.text, got, err, test.want)
+		}
+	}
+
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("expected an error")
+	}
+}
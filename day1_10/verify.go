@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"Aoc2022/answers"
+)
+
+func verifyCommand(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	path := fs.String("answers", "answers.json", "known answers `file`")
+	day := fs.Int("day", 0, "verify only this day")
+	update := fs.Bool("update", false, "overwrite the stored answers with the current results")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	entries, err := answers.Load(*path)
+	if err != nil {
+		return err
+	}
+
+	selected := entries
+	if *day != 0 {
+		selected = []answers.Entry{}
+		for _, entry := range entries {
+			if entry.Day == *day {
+				selected = append(selected, entry)
+			}
+		}
+	}
+
+	// answers.json の答えは問題文どおりの定数で解いたものなので, 設定ファイルの params は使わない
+	results := answers.Verify(context.Background(), selected, filepath.Dir(*path))
+	failed := 0
+	updated := map[string]answers.Entry{}
+	for _, result := range results {
+		entry := result.Entry
+		switch {
+		case result.Err != nil:
+			failed++
+			fmt.Printf("FAIL %s: %v\n", entry.Key(), result.Err)
+		case !result.Ok():
+			failed++
+			fmt.Printf("FAIL %s: answer changed\n%s", entry.Key(), answers.Diff(entry.Answer, result.Got))
+			entry.Answer = result.Got
+			updated[entry.Key()] = entry
+		default:
+			fmt.Printf("ok   %s\n", entry.Key())
+		}
+	}
+
+	if *update && len(updated) != 0 {
+		for idx, entry := range entries {
+			if changed, exists := updated[entry.Key()]; exists {
+				entries[idx] = changed
+			}
+		}
+		if err := answers.Save(*path, entries); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "updated %d answer(s) in %s\n", len(updated), *path)
+		// 答えが変わっただけのものは書き換えたので, 残りはエラーになったものだけ
+		failed -= len(updated)
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("%d of %d answer(s) failed", failed, len(results))
+	}
+	return nil
+}
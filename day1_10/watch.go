@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"Aoc2022/watch"
+)
+
+func watchCommand(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to watch")
+	part := fs.Int("part", 0, "part to run (1 or 2); both parts when omitted")
+	inputPath := fs.String("input", "", "puzzle input file (default <inputs>/dayN.txt)")
+	inputDir := fs.String("inputs", defaultInputDir, "directory holding dayN.txt")
+	interval := fs.Duration("interval", 500*time.Millisecond, "how often to look for changed files")
+	timeout := fs.Duration("timeout", 0, "time limit per part (0 for none)")
+	clear := fs.Bool("clear", true, "clear the terminal before each report")
+	configPath, overrides := paramFlags(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	if *day == 0 {
+		return errors.New("-day is required")
+	}
+	if _, err := selectEntries(*day, *part); err != nil {
+		return err
+	}
+	if *interval <= 0 {
+		return errors.New("-interval must be positive")
+	}
+	// 実行のたびに aoc run が読み直すが, 設定の誤りは見張り始める前に知らせる
+	if _, err := loadParams(*configPath, overrides); err != nil {
+		return err
+	}
+
+	path := *inputPath
+	if path == "" {
+		path = inputFile(*inputDir, *day)
+	}
+
+	runArgs := []string{"-timeout", timeout.String(), "-config", *configPath}
+	for key, value := range overrides {
+		runArgs = append(runArgs, "-set", key+"="+value)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	return watch.Watch(ctx, watch.Options{
+		Day:      *day,
+		Part:     *part,
+		Root:     ".",
+		Input:    path,
+		Interval: *interval,
+		RunArgs:  runArgs,
+		Clear:    *clear,
+	}, os.Stdout)
+}
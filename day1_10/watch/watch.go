@@ -0,0 +1,266 @@
+package watch
+
+/*
+watch
+
+1 日分のソースと入力を監視し, 変わるたびにビルドし直して解き, 例のテストを流す.
+結果は端末を書き換えて表示する.
+
+解法はコマンドに組み込まれているので, 変更を反映するには作り直すしかない.
+go build で作った aoc を run -format json で実行し, go test ./days/dayN を流す.
+*/
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aoc2022/runner"
+)
+
+// Options は監視の対象と実行のしかた
+type Options struct {
+	Day int
+	// 0 なら両パート
+	Part int
+	// go.mod のあるディレクトリ
+	Root string
+	// 入力ファイル. Root からの相対パスでもよい
+	Input string
+	// ファイルを見に行く間隔
+	Interval time.Duration
+	// aoc run にそのまま渡す引数 (-set, -timeout など)
+	RunArgs []string
+	// 表示の前に画面を消す
+	Clear bool
+}
+
+// Report は 1 回分の結果
+type Report struct {
+	Time time.Time
+	// 前回から変わったファイル. 初回は空
+	Changed []string
+	// ビルドに失敗したときのコンパイラの出力
+	BuildOutput string
+	Results     []runner.Result
+	// 結果を 1 つも読めなかったときの aoc run の出力
+	RunOutput string
+	// 失敗したテストの出力. 全て通れば空
+	TestFailures []string
+}
+
+// テストで差し替えられるようにしておく
+var command = func(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	return cmd.CombinedOutput()
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// sources は監視するファイル. 日のパッケージ (testdata を含む) と入力
+func (o Options) sources() []string {
+	return []string{filepath.Join(o.Root, "days", fmt.Sprintf("day%d", o.Day)), o.inputPath()}
+}
+
+func (o Options) inputPath() string {
+	if filepath.IsAbs(o.Input) {
+		return o.Input
+	}
+	return filepath.Join(o.Root, o.Input)
+}
+
+// snapshot は paths 以下の全てのファイルの更新時刻と大きさを集める. ないファイルは無視する
+func snapshot(paths []string) map[string]fileState {
+	result := map[string]fileState{}
+	for _, root := range paths {
+		filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return nil
+			}
+			if info, err := entry.Info(); err == nil {
+				result[path] = fileState{info.ModTime(), info.Size()}
+			}
+			return nil
+		})
+	}
+	return result
+}
+
+// changed は before から after で足された, 消された, 書き換えられたファイルを名前順で返す
+func changed(before, after map[string]fileState) []string {
+	result := []string{}
+	for path, state := range after {
+		if previous, exists := before[path]; !exists || previous != state {
+			result = append(result, path)
+		}
+	}
+	for path := range before {
+		if _, exists := after[path]; !exists {
+			result = append(result, path)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Watch は ctx が終わるまで監視し, 最初と変更のたびに結果を w に書く
+func Watch(ctx context.Context, options Options, w io.Writer) error {
+	dir, err := os.MkdirTemp("", "aoc-watch")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	binary := filepath.Join(dir, "aoc")
+
+	ticker := time.NewTicker(options.Interval)
+	defer ticker.Stop()
+
+	var previous map[string]fileState
+	for {
+		current := snapshot(options.sources())
+		if previous == nil || len(changed(previous, current)) != 0 {
+			report := Once(ctx, options, binary)
+			if previous != nil {
+				report.Changed = changed(previous, current)
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err := Render(w, options, report); err != nil {
+				return err
+			}
+			previous = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Once は binary にビルドし直して解き, テストを流した結果を返す
+func Once(ctx context.Context, options Options, binary string) Report {
+	report := Report{Time: time.Now()}
+
+	output, err := command(ctx, options.Root, "go", "build", "-o", binary, ".")
+	if err != nil {
+		report.BuildOutput = strings.TrimSpace(string(output))
+		return report
+	}
+
+	args := []string{"run", "-day", strconv.Itoa(options.Day), "-format", "json", "-input", options.Input}
+	if options.Part != 0 {
+		args = append(args, "-part", strconv.Itoa(options.Part))
+	}
+	// 失敗したパートがあると終了コードが 0 でなくなるので, エラーは見ずに出力を読む
+	output, _ = command(ctx, options.Root, binary, append(args, options.RunArgs...)...)
+	report.Results, report.RunOutput = parseResults(output)
+
+	output, err = command(ctx, options.Root, "go", "test", fmt.Sprintf("./days/day%d", options.Day))
+	if err != nil {
+		report.TestFailures = testFailures(output)
+	}
+	return report
+}
+
+// parseResults は aoc run -format json の出力を読む. JSON でない行 (エラーの説明など) は rest に残す
+func parseResults(output []byte) (results []runner.Result, rest string) {
+	others := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		result := runner.Result{}
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			others = append(others, scanner.Text())
+			continue
+		}
+		results = append(results, result)
+	}
+	return results, strings.TrimSpace(strings.Join(others, "\n"))
+}
+
+// 1 つのテストの失敗につき表示する行数
+const failureLines = 6
+
+// testFailures は go test の出力から --- FAIL の行とその説明を抜き出す.
+// テストがビルドできないときなど FAIL の行がなければ出力をそのまま返す
+func testFailures(output []byte) []string {
+	lines := strings.Split(strings.TrimRight(string(output), "\n"), "\n")
+
+	result := []string{}
+	for idx := 0; idx < len(lines); idx++ {
+		if !strings.Contains(lines[idx], "--- FAIL:") {
+			continue
+		}
+		result = append(result, strings.TrimSpace(lines[idx]))
+		for next := idx + 1; next < len(lines) && next <= idx+failureLines; next++ {
+			// 説明はテスト名の行より深く字下げされている
+			if !strings.HasPrefix(lines[next], "    ") || strings.Contains(lines[next], "--- ") {
+				break
+			}
+			result = append(result, "  "+strings.TrimSpace(lines[next]))
+		}
+	}
+	if len(result) == 0 {
+		return lines
+	}
+	return result
+}
+
+// Render は report を w に書く
+func Render(w io.Writer, options Options, report Report) error {
+	buffer := bytes.Buffer{}
+	if options.Clear {
+		// カーソルを左上に戻して画面を消す
+		buffer.WriteString("\x1b[H\x1b[2J")
+	}
+
+	target := fmt.Sprintf("day%d", options.Day)
+	if options.Part != 0 {
+		target += fmt.Sprintf(" part%d", options.Part)
+	}
+	fmt.Fprintf(&buffer, "%s  %s", target, report.Time.Format("15:04:05"))
+	if len(report.Changed) != 0 {
+		fmt.Fprintf(&buffer, "  (changed: %s)", strings.Join(report.Changed, ", "))
+	}
+	buffer.WriteString("\n\n")
+
+	switch {
+	case report.BuildOutput != "":
+		fmt.Fprintf(&buffer, "build failed:\n%s\n", report.BuildOutput)
+	case len(report.Results) == 0:
+		fmt.Fprintf(&buffer, "run failed:\n%s\n", report.RunOutput)
+	default:
+		for _, result := range report.Results {
+			if err := runner.WriteText(&buffer, result); err != nil {
+				return err
+			}
+		}
+		if len(report.TestFailures) == 0 {
+			buffer.WriteString("\ntests: ok\n")
+		} else {
+			fmt.Fprintf(&buffer, "\ntests: failed\n%s\n", strings.Join(report.TestFailures, "\n"))
+		}
+	}
+
+	fmt.Fprintf(&buffer, "\nwatching %s every %s (Ctrl-C to stop)\n", strings.Join(options.sources(), ", "), options.Interval)
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
@@ -0,0 +1,238 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"Aoc2022/runner"
+	"Aoc2022/solver"
+)
+
+func TestChanged(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.go")
+	b := filepath.Join(dir, "testdata", "b.txt")
+	os.MkdirAll(filepath.Dir(b), 0o755)
+	os.WriteFile(a, []byte("package a\n"), 0o644)
+	os.WriteFile(b, []byte("1\n"), 0o644)
+
+	before := snapshot([]string{dir, filepath.Join(dir, "missing.txt")})
+	if len(before) != 2 {
+		t.Fatalf("got %v, want 2 files", before)
+	}
+	if got := changed(before, snapshot([]string{dir})); len(got) != 0 {
+		t.Errorf("got %v, want no changes", got)
+	}
+
+	os.WriteFile(b, []byte("12\n"), 0o644)
+	c := filepath.Join(dir, "c.go")
+	os.WriteFile(c, nil, 0o644)
+	os.Remove(a)
+	got := changed(before, snapshot([]string{dir}))
+	if strings.Join(got, ",") != strings.Join([]string{a, c, b}, ",") {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestParseResults(t *testing.T) {
+	output := `{"day":8,"part":1,"answer":21,"duration":1500,"input":"x","status":"ok"}
+{"day":8,"part":2,"answer":null,"duration":0,"input":"x","status":"error","error":"empty forest"}
+aoc: 1 of 2 part(s) failed
+`
+	results, rest := parseResults([]byte(output))
+	if len(results) != 2 || !results[0].Answer.Equal(solver.Int(21)) || results[1].Err == nil {
+		t.Errorf("got %+v", results)
+	}
+	if rest != "aoc: 1 of 2 part(s) failed" {
+		t.Errorf("got rest %q", rest)
+	}
+}
+
+func TestTestFailures(t *testing.T) {
+	output := `--- FAIL: TestPartOne (0.00s)
+    --- FAIL: TestPartOne/mjqj (0.00s)
+        day6_test.go:25: got 8 (int), want 7 (int)
+--- FAIL: TestPartTwo (0.00s)
+    day6_test.go:33: got 1 (int), want 2 (int)
+FAIL
+FAIL	Aoc2022/days/day6	0.003s
+FAIL
+`
+	want := []string{
+		"--- FAIL: TestPartOne (0.00s)",
+		"--- FAIL: TestPartOne/mjqj (0.00s)",
+		"  day6_test.go:25: got 8 (int), want 7 (int)",
+		"--- FAIL: TestPartTwo (0.00s)",
+		"  day6_test.go:33: got 1 (int), want 2 (int)",
+	}
+	if got := testFailures([]byte(output)); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// テストがビルドできないときは出力をそのまま見せる
+	broken := "# Aoc2022/days/day6\ndays/day6/day6.go:3:1: syntax error\nFAIL\tAoc2022/days/day6 [build failed]\n"
+	if got := testFailures([]byte(broken)); len(got) != 3 {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestRender(t *testing.T) {
+	options := Options{Day: 8, Part: 2, Root: "root", Input: "inputs/day8.txt", Interval: time.Second}
+	report := Report{
+		Time:         time.Date(2022, 12, 8, 6, 0, 0, 0, time.UTC),
+		Changed:      []string{"root/days/day8/day8.go"},
+		Results:      []runner.Result{{Day: 8, Part: 2, Answer: solver.Int(8), Duration: time.Millisecond}},
+		TestFailures: []string{"--- FAIL: TestPartTwo (0.00s)"},
+	}
+
+	buffer := bytes.Buffer{}
+	if err := Render(&buffer, options, report); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"day8 part2  06:00:00  (changed: root/days/day8/day8.go)", "day8 part2: 8 (1ms)", "tests: failed\n--- FAIL: TestPartTwo"} {
+		if !strings.Contains(buffer.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, buffer.String())
+		}
+	}
+	if strings.HasPrefix(buffer.String(), "\x1b") {
+		t.Error("cleared the screen without Clear")
+	}
+
+	buffer.Reset()
+	report.BuildOutput = "day8.go:1:1: syntax error"
+	Render(&buffer, options, report)
+	if !strings.Contains(buffer.String(), "build failed:\nday8.go:1:1: syntax error") || strings.Contains(buffer.String(), "tests:") {
+		t.Errorf("unexpected output for a failed build:\n%s", buffer.String())
+	}
+}
+
+// fakeCommand は go build, aoc run, go test の代わりに決まった出力を返す
+type fakeCommand struct {
+	mu    sync.Mutex
+	calls []string
+	build error
+}
+
+func (f *fakeCommand) run(ctx context.Context, dir string, name string, args ...string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	call := strings.Join(append([]string{filepath.Base(name)}, args...), " ")
+	f.calls = append(f.calls, call)
+	switch {
+	case strings.HasPrefix(call, "go build"):
+		return []byte("build output"), f.build
+	case strings.HasPrefix(call, "go test"):
+		return []byte("ok\n"), nil
+	}
+	return []byte(`{"day":8,"part":2,"answer":8,"duration":1000,"input":"x","status":"ok"}` + "\n"), nil
+}
+
+func (f *fakeCommand) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.calls)
+}
+
+func useFake(t *testing.T, fake *fakeCommand) {
+	original := command
+	command = fake.run
+	t.Cleanup(func() { command = original })
+}
+
+func TestOnce(t *testing.T) {
+	fake := &fakeCommand{}
+	useFake(t, fake)
+
+	options := Options{Day: 8, Part: 2, Root: ".", Input: "inputs/day8.txt", RunArgs: []string{"-set", "a=1"}}
+	report := Once(context.Background(), options, "bin/aoc")
+	if len(report.Results) != 1 || report.TestFailures != nil || report.BuildOutput != "" {
+		t.Errorf("got %+v", report)
+	}
+	want := []string{
+		"go build -o bin/aoc .",
+		"aoc run -day 8 -format json -input inputs/day8.txt -part 2 -set a=1",
+		"go test ./days/day8",
+	}
+	if strings.Join(fake.calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got calls\n%s", strings.Join(fake.calls, "\n"))
+	}
+
+	// ビルドに失敗したら実行もテストもしない
+	fake.calls, fake.build = nil, errors.New("exit status 1")
+	report = Once(context.Background(), options, "bin/aoc")
+	if report.BuildOutput != "build output" || len(fake.calls) != 1 {
+		t.Errorf("got %+v after %v", report, fake.calls)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	fake := &fakeCommand{}
+	useFake(t, fake)
+
+	root := t.TempDir()
+	source := filepath.Join(root, "days", "day8", "day8.go")
+	os.MkdirAll(filepath.Dir(source), 0o755)
+	os.WriteFile(source, []byte("package day8\n"), 0o644)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	output := &syncBuffer{}
+	go func() {
+		done <- Watch(ctx, Options{Day: 8, Root: root, Input: "inputs/day8.txt", Interval: 5 * time.Millisecond}, output)
+	}()
+
+	waitFor(t, func() bool { return fake.count() == 3 })
+	// 変わっていなければやり直さない
+	time.Sleep(30 * time.Millisecond)
+	if fake.count() != 3 {
+		t.Errorf("got %d commands without a change, want 3", fake.count())
+	}
+
+	os.WriteFile(source, []byte("package day8\n\n"), 0o644)
+	waitFor(t, func() bool { return fake.count() == 6 })
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output.String(), "(changed: "+source+")") {
+		t.Errorf("the change is not reported:\n%s", output.String())
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+type syncBuffer struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.String()
+}